@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	pkgconfig "github.com/termkit/gama/pkg/config"
+)
+
+// authUsage is the usage of the auth command, it runs before the profile is used since the profile may have no token yet
+const authUsage = "login [--profile name] [--insecure-storage] < token"
+
+// The config functions are replaced in tests, they write to the keyring and the home directory
+var (
+	storeToken  = pkgconfig.StoreToken
+	saveConfig  = pkgconfig.SaveConfig
+	checkConfig = pkgconfig.CheckConfig
+)
+
+// Login keeps the token read from the standard input for the profile in the OS keyring. The profile is the one
+// given with --profile, or the selected profile of the config. If there is no config file, it is written with
+// the default profile reading its token from the keyring. Machines without a keyring need --insecure-storage
+// to write the token in plain text to the credentials file.
+func Login(cfg *pkgconfig.Config, profile string, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	err := login(cfg, profile, args, stdin, stdout, stderr)
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		fmt.Fprintf(stdout, "Usage: gama auth %s\n", authUsage)
+		return ExitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "gama auth: %v\nUsage: gama auth %s\n", err, authUsage)
+		return ExitUsage
+	default:
+		fmt.Fprintf(stderr, "gama auth: %v\n", err)
+		return ExitError
+	}
+}
+
+func login(cfg *pkgconfig.Config, profile string, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "login" {
+		return usageErrorf("expected the login subcommand")
+	}
+
+	flags := flag.NewFlagSet("auth login", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&profile, "profile", profile, "profile to keep the token for")
+	insecureStorage := flags.Bool("insecure-storage", false, "write the token in plain text to the credentials file if there is no keyring")
+	if _, err := parseFlags(flags, args[1:], 0); err != nil {
+		return err
+	}
+
+	if profile == "" {
+		profile = cfg.Profile
+	}
+	profile = strings.ToLower(profile)
+	if profile == "" {
+		profile = pkgconfig.DefaultProfile
+	}
+
+	var hasConfig = checkConfig() == nil
+	if !hasConfig && profile != pkgconfig.DefaultProfile {
+		return fmt.Errorf("profile %q is not found, there is no config file", profile)
+	} else if hasConfig && !slices.Contains(cfg.ProfileNames(), profile) {
+		return fmt.Errorf("profile %q is not found, the profiles are %s", profile, strings.Join(cfg.ProfileNames(), ", "))
+	}
+
+	token, err := readToken(stdin)
+	if err != nil {
+		return err
+	}
+
+	where, err := storeToken(profile, token, false)
+	if errors.Is(err, pkgconfig.ErrNoKeyring) {
+		if !*insecureStorage {
+			return fmt.Errorf("%w, give --insecure-storage to write the token in plain text to the credentials file", err)
+		}
+		fmt.Fprintln(stderr, "Warning: there is no keyring, the token is written in plain text to the credentials file")
+		where, err = storeToken(profile, token, true)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Token of profile %s is kept in %s\n", profile, where)
+
+	if !hasConfig {
+		var config = *cfg
+		config.Github.Token = ""
+		config.Github.TokenSource = pkgconfig.TokenSourceKeyring
+		if err := saveConfig(&config); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, "The config file is written with the keyring as the token source")
+		return nil
+	}
+
+	var github = cfg.Github
+	if profile != pkgconfig.DefaultProfile {
+		github = cfg.Profiles[profile]
+	}
+	if github.TokenSource != pkgconfig.TokenSourceKeyring {
+		fmt.Fprintln(stdout, "Set token_source: keyring in the profile to use it")
+	}
+	return nil
+}
+
+// readToken reads the token from the first line of the input, like `gh auth token | gama auth login`
+func readToken(stdin io.Reader) (string, error) {
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read the token: %w", err)
+	}
+
+	token := strings.TrimSpace(line)
+	if token == "" {
+		return "", usageErrorf("the token is read from the standard input, it is empty")
+	}
+	return token, nil
+}
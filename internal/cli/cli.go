@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	gu "github.com/termkit/gama/internal/github/usecase"
+)
+
+// Exit codes of the commands, scripts can tell a failed run apart from a failed command
+const (
+	ExitOK        = 0 // the command succeeded
+	ExitError     = 1 // the command failed, like an API error
+	ExitUsage     = 2 // the command or its arguments are invalid
+	ExitRunFailed = 3 // the awaited run completed without success
+)
+
+// errRunFailed is returned by the commands that wait for a run which does not succeed
+var errRunFailed = errors.New("the run did not succeed")
+
+// usageError is returned for invalid arguments, the usage of the command is printed with it
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+type command struct {
+	name        string
+	usage       string // arguments of the command
+	description string
+	run         func(c *CLI, ctx context.Context, args []string) error
+}
+
+// commands are the subcommands of gama, gama starts the terminal UI without one
+var commands = []command{
+	{name: "repos", usage: "[--limit n]", description: "List the repositories", run: (*CLI).repos},
+	{name: "runs", usage: "<owner/repo> [--branch name] [--workflow file] [--status status] [--limit n]", description: "List the workflow runs of a branch, the default branch if it is not given", run: (*CLI).runs},
+	{name: "trigger", usage: "<owner/repo> <workflow file> [--ref branch] [--input key=value]... [--wait | --no-wait]", description: "Trigger a workflow, --wait waits for its run and fails if the run does not succeed, --no-wait does not look up the run", run: (*CLI).trigger},
+	{name: "rerun", usage: "<owner/repo> <run id> [--failed] [--wait]", description: "Re-run a workflow run, or only its failed jobs", run: (*CLI).rerun},
+	{name: "cancel", usage: "<owner/repo> <run id>", description: "Cancel a workflow run", run: (*CLI).cancel},
+	{name: "logs", usage: "<owner/repo> <run id> [--job name]", description: "Print the logs of the jobs of a workflow run", run: (*CLI).logs},
+}
+
+// IsCommand reports whether the argument is a subcommand, or asks for their help
+func IsCommand(name string) bool {
+	if name == "help" || name == "auth" {
+		return true
+	}
+	_, ok := findCommand(name)
+	return ok
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// CLI runs the subcommands of gama with the use cases of the terminal UI, for scripts and CI
+type CLI struct {
+	githubUseCase     gu.UseCase
+	repositorySources gu.RepositorySources
+
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func New(githubUseCase gu.UseCase, repositorySources gu.RepositorySources, stdout io.Writer, stderr io.Writer) *CLI {
+	return &CLI{
+		githubUseCase:     githubUseCase,
+		repositorySources: repositorySources,
+		stdout:            stdout,
+		stderr:            stderr,
+	}
+}
+
+// Run runs the subcommand in args, like ["runs", "owner/repo", "--branch", "main"], and returns the exit code
+func (c *CLI) Run(ctx context.Context, args []string) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		c.printUsage()
+		return ExitOK
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(c.stderr, "gama: unknown command %q\n\n", args[0])
+		c.printUsage()
+		return ExitUsage
+	}
+
+	err := cmd.run(c, ctx, args[1:])
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		fmt.Fprintf(c.stdout, "Usage: gama %s %s\n", cmd.name, cmd.usage)
+		return ExitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(c.stderr, "gama %s: %v\nUsage: gama %s %s\n", cmd.name, err, cmd.name, cmd.usage)
+		return ExitUsage
+	case errors.Is(err, errRunFailed):
+		fmt.Fprintf(c.stderr, "gama %s: %v\n", cmd.name, err)
+		return ExitRunFailed
+	default:
+		fmt.Fprintf(c.stderr, "gama %s: %s\n", cmd.name, describeError(err))
+		return ExitError
+	}
+}
+
+func (c *CLI) printUsage() {
+	fmt.Fprintln(c.stdout, "Usage: gama [--profile name] [command] [arguments] [--output table|json|yaml]")
+	fmt.Fprintln(c.stdout, "\nThe terminal UI is started without a command.\n\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(c.stdout, "  %-8s %s\n", cmd.name, cmd.description)
+		fmt.Fprintf(c.stdout, "  %-8s gama %s %s\n", "", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(c.stdout, "  %-8s %s\n", "auth", "Keep the token of a profile in the OS keyring, it is read from the standard input")
+	fmt.Fprintf(c.stdout, "  %-8s gama auth %s\n", "", authUsage)
+	fmt.Fprintf(c.stdout, "\nExit codes: %d success, %d error, %d invalid arguments, %d the awaited run did not succeed\n",
+		ExitOK, ExitError, ExitUsage, ExitRunFailed)
+}
+
+// describeError adds what the user can do about the error of the GitHub API
+func describeError(err error) string {
+	var apiError *gr.APIError
+	if errors.As(err, &apiError) {
+		if missingScopes := apiError.MissingScopes(); len(missingScopes) > 0 {
+			return fmt.Sprintf("%v (%d), the token is missing the %s scope", err, apiError.StatusCode, strings.Join(missingScopes, " or "))
+		}
+		return fmt.Sprintf("%v (%d)", err, apiError.StatusCode)
+	}
+	return err.Error()
+}
+
+// newFlagSet returns the flags of a command with the --output flag, errors are returned instead of printed.
+// The global --profile flag selects the client before the command runs, it is refused after the command.
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	output := flags.String("output", outputTable, "output format, table, json or yaml")
+	flags.Var(new(globalFlag), "profile", "profile of the config file, given before the command")
+	return flags, output
+}
+
+// parseFlags parses the flags that are given before, between or after the positional arguments,
+// like "runs owner/repo --branch main", and checks the number of positional arguments
+func parseFlags(flags *flag.FlagSet, args []string, positionals int) ([]string, error) {
+	var arguments []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usageError{message: err.Error()}
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		arguments = append(arguments, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	if profileFlag := flags.Lookup("profile"); profileFlag != nil {
+		if profile, ok := profileFlag.Value.(*globalFlag); ok && *profile != "" {
+			return nil, usageErrorf("--profile is a global flag, give it before the command, like gama --profile %s %s", *profile, flags.Name())
+		}
+	}
+	if len(arguments) != positionals {
+		return nil, usageErrorf("expected %d positional arguments, got %d", positionals, len(arguments))
+	}
+	return arguments, nil
+}
+
+// stringsFlag is a flag that can be given several times, like --input a=1 --input b=2
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// globalFlag is a global flag that is given after the command, parseFlags refuses it
+type globalFlag string
+
+func (f *globalFlag) String() string {
+	return string(*f)
+}
+
+func (f *globalFlag) Set(value string) error {
+	*f = globalFlag(value)
+	return nil
+}
+
+// sortedKeys returns the keys of the map in order, for a stable output
+func sortedKeys[V any](m map[string]V) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
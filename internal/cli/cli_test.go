@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gr "github.com/termkit/gama/internal/github/repository"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	pkgconfig "github.com/termkit/gama/pkg/config"
+	pw "github.com/termkit/gama/pkg/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// fakeUseCase answers the requests of the commands, the unused methods of the use case panic
+type fakeUseCase struct {
+	gu.UseCase
+
+	triggered  gu.TriggerWorkflowInput
+	conclusion string
+	cancelled  int64
+	rerunStart bool // whether the re-run starts, the watch of the re-run fails otherwise
+}
+
+func (u *fakeUseCase) ListRepositories(ctx context.Context, input gu.ListRepositoriesInput) (*gu.ListRepositoriesOutput, error) {
+	var repositories = []gu.GithubRepository{
+		{Name: "termkit/gama", DefaultBranch: "main", Stars: 10, Workflows: []gu.Workflow{{ID: 1}}},
+		{Name: "acme/api", Private: true, DefaultBranch: "trunk"},
+	}
+	return &gu.ListRepositoriesOutput{Repositories: repositories}, nil
+}
+
+func (u *fakeUseCase) GetRepository(ctx context.Context, input gu.GetRepositoryInput) (*gu.GetRepositoryOutput, error) {
+	repositories, _ := u.ListRepositories(ctx, gu.ListRepositoriesInput{})
+	for _, repository := range repositories.Repositories {
+		if repository.Name == input.Repository {
+			return &gu.GetRepositoryOutput{Repository: repository}, nil
+		}
+	}
+	return nil, &gr.APIError{StatusCode: http.StatusNotFound, Message: "Not Found"}
+}
+
+func (u *fakeUseCase) GetWorkflowHistory(ctx context.Context, input gu.GetWorkflowHistoryInput) (*gu.GetWorkflowHistoryOutput, error) {
+	return &gu.GetWorkflowHistoryOutput{Workflows: []gu.Workflow{
+		{ID: 42, WorkflowName: "CI", ActionName: "Fix build", TriggeredBy: "octocat", Status: "completed", Conclusion: "failure"},
+	}}, nil
+}
+
+func (u *fakeUseCase) InspectWorkflow(ctx context.Context, input gu.InspectWorkflowInput) (*gu.InspectWorkflowOutput, error) {
+	return &gu.InspectWorkflowOutput{Workflow: &pw.Pretty{
+		Choices: []pw.PrettyChoice{{Key: "environment", Values: []string{"staging", "production"}, Default: "staging"}},
+		Inputs:  []pw.PrettyInput{{Key: "version", Default: "latest"}},
+		Boolean: []pw.PrettyInput{{Key: "dry_run", Default: "true"}},
+	}}, nil
+}
+
+func (u *fakeUseCase) TriggerWorkflow(ctx context.Context, input gu.TriggerWorkflowInput) (*gu.TriggerWorkflowOutput, error) {
+	u.triggered = input
+	return &gu.TriggerWorkflowOutput{RunID: 7, Status: "queued", HTMLURL: "https://github.com/acme/api/actions/runs/7"}, nil
+}
+
+func (u *fakeUseCase) GetWorkflowRun(ctx context.Context, input gu.GetWorkflowRunInput) (*gu.GetWorkflowRunOutput, error) {
+	return &gu.GetWorkflowRunOutput{Status: "completed", Conclusion: "failure", Attempt: 1}, nil
+}
+
+func (u *fakeUseCase) ReRunWorkflow(ctx context.Context, input gu.ReRunWorkflowInput) (*gu.ReRunWorkflowOutput, error) {
+	return &gu.ReRunWorkflowOutput{}, nil
+}
+
+func (u *fakeUseCase) WatchRun(ctx context.Context, input gu.WatchRunInput) (*gu.WatchRunOutput, error) {
+	if input.PreviousAttempt > 0 && !u.rerunStart {
+		updates := make(chan gu.RunUpdate, 1)
+		updates <- gu.RunUpdate{Err: &gu.RunNotStartedError{RunID: input.WorkflowID, PreviousAttempt: input.PreviousAttempt, Timeout: time.Minute}}
+		close(updates)
+		return &gu.WatchRunOutput{Updates: updates}, nil
+	}
+
+	updates := make(chan gu.RunUpdate, 2)
+	updates <- gu.RunUpdate{Status: "in_progress"}
+	updates <- gu.RunUpdate{Status: "completed", Conclusion: u.conclusion,
+		Transitions: []gu.Transition{{From: "in_progress", To: u.conclusion}}}
+	close(updates)
+	return &gu.WatchRunOutput{Updates: updates}, nil
+}
+
+func (u *fakeUseCase) CancelWorkflow(ctx context.Context, input gu.CancelWorkflowInput) (*gu.CancelWorkflowOutput, error) {
+	u.cancelled = input.WorkflowID
+	return &gu.CancelWorkflowOutput{}, nil
+}
+
+func run(useCase gu.UseCase, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := New(useCase, gu.RepositorySources{}, &stdout, &stderr).Run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI_Repos(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		code, stdout, _ := run(&fakeUseCase{}, "repos")
+		assert.Equal(t, ExitOK, code)
+		assert.Equal(t, "REPOSITORY    VISIBILITY  DEFAULT BRANCH  STARS  WORKFLOWS\n"+
+			"acme/api      private     trunk           0      0\n"+
+			"termkit/gama  public      main            10     1\n", stdout)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		code, stdout, _ := run(&fakeUseCase{}, "repos", "--output", "json")
+		assert.Equal(t, ExitOK, code)
+
+		var repositories []repositoryOutput
+		require.NoError(t, json.Unmarshal([]byte(stdout), &repositories))
+		assert.Equal(t, []string{"acme/api", "termkit/gama"}, []string{repositories[0].Name, repositories[1].Name})
+	})
+}
+
+func TestCLI_Runs(t *testing.T) {
+	code, stdout, _ := run(&fakeUseCase{}, "runs", "acme/api", "--branch", "main", "--output", "yaml")
+	assert.Equal(t, ExitOK, code)
+
+	var runs []runOutput
+	require.NoError(t, yaml.Unmarshal([]byte(stdout), &runs))
+	assert.Equal(t, []runOutput{{ID: 42, Workflow: "CI", Title: "Fix build", Actor: "octocat", Status: "completed", Conclusion: "failure"}}, runs)
+
+	code, _, stderr := run(&fakeUseCase{}, "runs")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "Usage: gama runs")
+
+	code, _, stderr = run(&fakeUseCase{}, "runs", "termkit/gama", "--profile", "work")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "give it before the command, like gama --profile work runs")
+}
+
+func TestCLI_Trigger(t *testing.T) {
+	t.Run("inputs", func(t *testing.T) {
+		useCase := &fakeUseCase{}
+		code, stdout, _ := run(useCase, "trigger", "acme/api", "deploy.yml", "--input", "environment=production", "--input", "version=1.2.0")
+		assert.Equal(t, ExitOK, code)
+		assert.Contains(t, stdout, "https://github.com/acme/api/actions/runs/7")
+
+		assert.Equal(t, "trunk", useCase.triggered.Branch, "the default branch is used without --ref")
+		var inputs map[string]string
+		require.NoError(t, json.Unmarshal([]byte(useCase.triggered.Content), &inputs))
+		assert.Equal(t, map[string]string{"environment": "production", "version": "1.2.0", "dry_run": "true"}, inputs)
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		code, _, stderr := run(&fakeUseCase{}, "trigger", "acme/api", "deploy.yml", "--ref", "main", "--input", "region=eu")
+		assert.Equal(t, ExitUsage, code)
+		assert.Contains(t, stderr, "the workflow has no input region")
+
+		code, _, stderr = run(&fakeUseCase{}, "trigger", "acme/api", "deploy.yml", "--ref", "main", "--input", "environment=qa")
+		assert.Equal(t, ExitUsage, code)
+		assert.Contains(t, stderr, "expected one of staging, production")
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		code, stdout, _ := run(&fakeUseCase{conclusion: "success"}, "trigger", "acme/api", "deploy.yml", "--ref", "main", "--wait", "--output", "json")
+		assert.Equal(t, ExitOK, code)
+
+		var result triggerOutput
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+		assert.Equal(t, "success", result.Conclusion)
+
+		code, _, stderr := run(&fakeUseCase{conclusion: "failure"}, "trigger", "acme/api", "deploy.yml", "--ref", "main", "--wait")
+		assert.Equal(t, ExitRunFailed, code)
+		assert.Contains(t, stderr, "run 7 concluded with failure")
+	})
+
+	t.Run("no wait", func(t *testing.T) {
+		useCase := &fakeUseCase{}
+		code, _, _ := run(useCase, "trigger", "acme/api", "deploy.yml", "--ref", "main", "--no-wait")
+		assert.Equal(t, ExitOK, code)
+		assert.True(t, useCase.triggered.NoWait)
+
+		code, _, stderr := run(useCase, "trigger", "acme/api", "deploy.yml", "--ref", "main", "--wait", "--no-wait")
+		assert.Equal(t, ExitUsage, code)
+		assert.Contains(t, stderr, "--wait and --no-wait cannot be given together")
+	})
+}
+
+func TestCLI_Rerun(t *testing.T) {
+	t.Run("wait", func(t *testing.T) {
+		code, stdout, _ := run(&fakeUseCase{conclusion: "success", rerunStart: true}, "rerun", "acme/api", "42", "--wait", "--output", "json")
+		assert.Equal(t, ExitOK, code)
+
+		var result runActionOutput
+		require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+		assert.Equal(t, "success", result.Conclusion)
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		code, _, stderr := run(&fakeUseCase{conclusion: "success"}, "rerun", "acme/api", "42", "--wait")
+		assert.Equal(t, ExitError, code)
+		assert.Contains(t, stderr, "run 42 is not re-run")
+	})
+}
+
+func TestCLI_Cancel(t *testing.T) {
+	useCase := &fakeUseCase{}
+	code, _, _ := run(useCase, "cancel", "acme/api", "42")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, int64(42), useCase.cancelled)
+
+	code, _, stderr := run(useCase, "cancel", "acme/api", "latest")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, `invalid run id "latest"`)
+}
+
+func TestLogin(t *testing.T) {
+	var stored = make(map[string]string)
+	var saved *pkgconfig.Config
+	var hasConfig = true
+	var hasKeyring = true
+	storeToken = func(profile string, token string, insecureStorage bool) (string, error) {
+		if !hasKeyring && !insecureStorage {
+			return "", pkgconfig.ErrNoKeyring
+		} else if !hasKeyring {
+			stored[profile] = token
+			return "credentials.yml", nil
+		}
+		stored[profile] = token
+		return "keyring", nil
+	}
+	saveConfig = func(config *pkgconfig.Config) error {
+		saved = config
+		return nil
+	}
+	checkConfig = func() error {
+		if hasConfig {
+			return nil
+		}
+		return errors.New("config file does not exist")
+	}
+	t.Cleanup(func() {
+		storeToken, saveConfig, checkConfig = pkgconfig.StoreToken, pkgconfig.SaveConfig, pkgconfig.CheckConfig
+	})
+
+	cfg := &pkgconfig.Config{Profiles: map[string]pkgconfig.Github{"work": {TokenSource: pkgconfig.TokenSourceKeyring}}}
+	login := func(profile string, stdin string, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := Login(cfg, profile, args, strings.NewReader(stdin), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, stdout, _ := login("", "ghp_work\n", "login", "--profile", "Work")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "ghp_work", stored["work"])
+	assert.Equal(t, "Token of profile work is kept in keyring\n", stdout)
+
+	code, _, _ = login("", "ghp_default")
+	assert.Equal(t, ExitUsage, code)
+
+	// The keyring is not the token source of the default profile yet
+	code, stdout, _ = login("", "ghp_default", "login")
+	assert.Equal(t, ExitOK, code)
+	assert.Equal(t, "ghp_default", stored[pkgconfig.DefaultProfile])
+	assert.Contains(t, stdout, "Set token_source: keyring")
+
+	code, _, stderr := login("ops", "ghp_ops", "login")
+	assert.Equal(t, ExitError, code)
+	assert.Contains(t, stderr, `profile "ops" is not found`)
+
+	code, _, stderr = login("work", "\n", "login")
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, stderr, "the token is read from the standard input")
+
+	// The token is written in plain text only if it is asked for, with a warning
+	hasKeyring = false
+	code, _, stderr = login("work", "ghp_plain", "login")
+	assert.Equal(t, ExitError, code)
+	assert.Contains(t, stderr, "--insecure-storage")
+	assert.Equal(t, "ghp_work", stored["work"])
+
+	code, stdout, stderr = login("work", "ghp_plain", "login", "--insecure-storage")
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, stderr, "Warning: there is no keyring")
+	assert.Contains(t, stdout, "kept in credentials.yml")
+	hasKeyring = true
+
+	// Without a config file, it is written for the default profile, the token is not in it
+	hasConfig = false
+	code, _, _ = login("", "ghp_new", "login")
+	assert.Equal(t, ExitOK, code)
+	require.NotNil(t, saved)
+	assert.Equal(t, "ghp_new", stored[pkgconfig.DefaultProfile])
+	assert.Empty(t, saved.Github.Token)
+	assert.Equal(t, pkgconfig.TokenSourceKeyring, saved.Github.TokenSource)
+
+	code, _, _ = login("work", "ghp_work", "login")
+	assert.Equal(t, ExitError, code)
+}
@@ -0,0 +1,540 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	gu "github.com/termkit/gama/internal/github/usecase"
+	pw "github.com/termkit/gama/pkg/workflow"
+)
+
+// defaultRunsLimit is the number of runs listed if --limit is not given
+const defaultRunsLimit = 20
+
+type repositoryOutput struct {
+	Name          string `json:"name" yaml:"name"`
+	Private       bool   `json:"private" yaml:"private"`
+	DefaultBranch string `json:"default_branch" yaml:"default_branch"`
+	Stars         int    `json:"stars" yaml:"stars"`
+	Workflows     int    `json:"workflows" yaml:"workflows"`
+	URL           string `json:"url" yaml:"url"`
+}
+
+func (c *CLI) repos(ctx context.Context, args []string) error {
+	flags, output := newFlagSet("repos")
+	limit := flags.Int("limit", 0, "maximum number of repositories, 0 lists all of them")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	repositories, err := c.githubUseCase.ListRepositories(ctx, gu.ListRepositoriesInput{
+		Limit:   *limit,
+		Sources: c.repositorySources,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The repositories are collected concurrently, they are sorted for a stable output
+	sort.Slice(repositories.Repositories, func(i, j int) bool {
+		return strings.ToLower(repositories.Repositories[i].Name) < strings.ToLower(repositories.Repositories[j].Name)
+	})
+
+	var result = make([]repositoryOutput, 0, len(repositories.Repositories))
+	var rows [][]string
+	for _, repository := range repositories.Repositories {
+		result = append(result, repositoryOutput{
+			Name:          repository.Name,
+			Private:       repository.Private,
+			DefaultBranch: repository.DefaultBranch,
+			Stars:         repository.Stars,
+			Workflows:     len(repository.Workflows),
+			URL:           repository.HTMLURL,
+		})
+
+		var visibility = "public"
+		if repository.Private {
+			visibility = "private"
+		}
+		rows = append(rows, []string{repository.Name, visibility, repository.DefaultBranch,
+			strconv.Itoa(repository.Stars), strconv.Itoa(len(repository.Workflows))})
+	}
+
+	return c.print(*output, table{
+		columns: []string{"REPOSITORY", "VISIBILITY", "DEFAULT BRANCH", "STARS", "WORKFLOWS"},
+		rows:    rows,
+		value:   result,
+	})
+}
+
+type runOutput struct {
+	ID         int64  `json:"id" yaml:"id"`
+	Workflow   string `json:"workflow" yaml:"workflow"`
+	Title      string `json:"title" yaml:"title"`
+	Actor      string `json:"actor" yaml:"actor"`
+	Status     string `json:"status" yaml:"status"`
+	Conclusion string `json:"conclusion" yaml:"conclusion"`
+	StartedAt  string `json:"started_at" yaml:"started_at"`
+	Duration   string `json:"duration" yaml:"duration"`
+	URL        string `json:"url" yaml:"url"`
+}
+
+func (c *CLI) runs(ctx context.Context, args []string) error {
+	flags, output := newFlagSet("runs")
+	branch := flags.String("branch", "", "branch of the runs, the default branch if it is empty")
+	workflow := flags.String("workflow", "", "file name of the workflow, like deploy.yml")
+	status := flags.String("status", "", "status or conclusion of the runs, like failure")
+	event := flags.String("event", "", "event that triggered the runs, like push")
+	actor := flags.String("actor", "", "login of the user who triggered the runs")
+	limit := flags.Int("limit", defaultRunsLimit, "maximum number of runs, 0 lists all of them")
+	arguments, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	history, err := c.githubUseCase.GetWorkflowHistory(ctx, gu.GetWorkflowHistoryInput{
+		Repository: arguments[0],
+		Branch:     *branch,
+		Limit:      *limit,
+		Filter: gu.HistoryFilter{
+			Workflow: *workflow,
+			Status:   *status,
+			Event:    *event,
+			Actor:    *actor,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var result = make([]runOutput, 0, len(history.Workflows))
+	var rows [][]string
+	for _, run := range history.Workflows {
+		result = append(result, runOutput{
+			ID:         run.ID,
+			Workflow:   run.WorkflowName,
+			Title:      run.ActionName,
+			Actor:      run.TriggeredBy,
+			Status:     run.Status,
+			Conclusion: run.Conclusion,
+			StartedAt:  run.StartedAt,
+			Duration:   run.Duration,
+			URL:        run.HTMLURL,
+		})
+		rows = append(rows, []string{strconv.FormatInt(run.ID, 10), run.WorkflowName, truncate(run.ActionName, 50),
+			run.TriggeredBy, runState(run.Status, run.Conclusion), run.StartedAt, run.Duration})
+	}
+
+	return c.print(*output, table{
+		columns: []string{"ID", "WORKFLOW", "TITLE", "ACTOR", "STATUS", "STARTED AT", "DURATION"},
+		rows:    rows,
+		value:   result,
+	})
+}
+
+type triggerOutput struct {
+	Repository string `json:"repository" yaml:"repository"`
+	Workflow   string `json:"workflow" yaml:"workflow"`
+	Ref        string `json:"ref" yaml:"ref"`
+	RunID      int64  `json:"run_id" yaml:"run_id"`
+	Status     string `json:"status" yaml:"status"`
+	Conclusion string `json:"conclusion" yaml:"conclusion"`
+	URL        string `json:"url" yaml:"url"`
+}
+
+func (c *CLI) trigger(ctx context.Context, args []string) error {
+	flags, output := newFlagSet("trigger")
+	ref := flags.String("ref", "", "branch the workflow runs on, the default branch if it is empty")
+	var inputs stringsFlag
+	flags.Var(&inputs, "input", "workflow input as key=value, it can be given several times")
+	wait := flags.Bool("wait", false, "wait for the run to complete, the exit code is 3 if it does not succeed")
+	noWait := flags.Bool("no-wait", false, "return right after the dispatch, without looking up the run")
+	arguments, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+	if *wait && *noWait {
+		return usageErrorf("--wait and --no-wait cannot be given together")
+	}
+
+	var repository, workflowFile = arguments[0], arguments[1]
+	values, err := parseInputs(inputs)
+	if err != nil {
+		return err
+	}
+
+	var branch = *ref
+	if branch == "" {
+		branch, err = c.defaultBranch(ctx, repository)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The inputs are filled like the trigger tab does, with the defaults of the workflow file on the branch
+	inspected, err := c.githubUseCase.InspectWorkflow(ctx, gu.InspectWorkflowInput{
+		Repository:   repository,
+		Branch:       branch,
+		WorkflowFile: workflowFile,
+	})
+	if err != nil {
+		return err
+	}
+	if err := applyInputs(inspected.Workflow, values); err != nil {
+		return err
+	}
+	content, err := inspected.Workflow.ToJson()
+	if err != nil {
+		return err
+	}
+
+	triggered, err := c.githubUseCase.TriggerWorkflow(ctx, gu.TriggerWorkflowInput{
+		Repository:   repository,
+		Branch:       branch,
+		WorkflowFile: workflowFile,
+		Content:      content,
+		NoWait:       *noWait,
+	})
+	if err != nil {
+		return err
+	}
+
+	var result = triggerOutput{
+		Repository: repository,
+		Workflow:   workflowFile,
+		Ref:        branch,
+		RunID:      triggered.RunID,
+		Status:     triggered.Status,
+		URL:        triggered.HTMLURL,
+	}
+
+	var waitErr error
+	if *wait {
+		if triggered.RunID == 0 {
+			return fmt.Errorf("the workflow is triggered but its run is not found, it cannot be awaited")
+		}
+		result.Status, result.Conclusion, waitErr = c.waitRun(ctx, repository, triggered.RunID, 0)
+		if result.Status == "" {
+			return waitErr
+		}
+	}
+
+	if err := c.print(*output, table{
+		columns: []string{"RUN ID", "REF", "STATUS", "URL"},
+		rows:    [][]string{{formatRunID(result.RunID), branch, runState(result.Status, result.Conclusion), result.URL}},
+		value:   result,
+	}); err != nil {
+		return err
+	}
+	return waitErr
+}
+
+type runActionOutput struct {
+	Repository string `json:"repository" yaml:"repository"`
+	RunID      int64  `json:"run_id" yaml:"run_id"`
+	Action     string `json:"action" yaml:"action"`
+	Status     string `json:"status,omitempty" yaml:"status,omitempty"`
+	Conclusion string `json:"conclusion,omitempty" yaml:"conclusion,omitempty"`
+}
+
+func (c *CLI) rerun(ctx context.Context, args []string) error {
+	flags, output := newFlagSet("rerun")
+	failed := flags.Bool("failed", false, "re-run only the failed jobs")
+	wait := flags.Bool("wait", false, "wait for the run to complete, the exit code is 3 if it does not succeed")
+	arguments, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	repository, runID, err := parseRun(arguments)
+	if err != nil {
+		return err
+	}
+
+	// The attempt is read before the re-run, the updates of this attempt are not the re-run
+	var previousAttempt int
+	if *wait {
+		workflowRun, err := c.githubUseCase.GetWorkflowRun(ctx, gu.GetWorkflowRunInput{Repository: repository, WorkflowID: runID})
+		if err != nil {
+			return err
+		}
+		previousAttempt = max(workflowRun.Attempt, 1)
+	}
+
+	var result = runActionOutput{Repository: repository, RunID: runID, Action: "rerun"}
+	if *failed {
+		result.Action = "rerun failed jobs"
+		_, err = c.githubUseCase.ReRunFailedJobs(ctx, gu.ReRunFailedJobsInput{Repository: repository, WorkflowID: runID})
+	} else {
+		_, err = c.githubUseCase.ReRunWorkflow(ctx, gu.ReRunWorkflowInput{Repository: repository, WorkflowID: runID})
+	}
+	if err != nil {
+		return err
+	}
+
+	var waitErr error
+	if *wait {
+		result.Status, result.Conclusion, waitErr = c.waitRun(ctx, repository, runID, previousAttempt)
+		if result.Status == "" {
+			return waitErr
+		}
+	}
+
+	if err := c.printRunAction(*output, result); err != nil {
+		return err
+	}
+	return waitErr
+}
+
+func (c *CLI) cancel(ctx context.Context, args []string) error {
+	flags, output := newFlagSet("cancel")
+	arguments, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	repository, runID, err := parseRun(arguments)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.githubUseCase.CancelWorkflow(ctx, gu.CancelWorkflowInput{Repository: repository, WorkflowID: runID}); err != nil {
+		return err
+	}
+
+	return c.printRunAction(*output, runActionOutput{Repository: repository, RunID: runID, Action: "cancel"})
+}
+
+func (c *CLI) printRunAction(output string, result runActionOutput) error {
+	return c.print(output, table{
+		columns: []string{"REPOSITORY", "RUN ID", "ACTION", "STATUS"},
+		rows:    [][]string{{result.Repository, formatRunID(result.RunID), result.Action, runState(result.Status, result.Conclusion)}},
+		value:   result,
+	})
+}
+
+type jobLogOutput struct {
+	Job   string   `json:"job" yaml:"job"`
+	Lines []string `json:"lines" yaml:"lines"`
+}
+
+func (c *CLI) logs(ctx context.Context, args []string) error {
+	flags, output := newFlagSet("logs")
+	job := flags.String("job", "", "print only the jobs whose name contains it")
+	arguments, err := parseFlags(flags, args, 2)
+	if err != nil {
+		return err
+	}
+	if err := checkOutput(*output); err != nil {
+		return err
+	}
+
+	repository, runID, err := parseRun(arguments)
+	if err != nil {
+		return err
+	}
+
+	logs, err := c.githubUseCase.GetWorkflowRunLogs(ctx, gu.GetWorkflowRunLogsInput{Repository: repository, WorkflowID: runID})
+	if err != nil {
+		return err
+	}
+
+	var result []jobLogOutput
+	for _, log := range logs.Logs {
+		if *job != "" && !strings.Contains(strings.ToLower(log.JobName), strings.ToLower(*job)) {
+			continue
+		}
+
+		var lines = make([]string, 0, len(log.Lines))
+		for _, line := range log.Lines {
+			lines = append(lines, line.Text)
+		}
+		result = append(result, jobLogOutput{Job: log.JobName, Lines: lines})
+	}
+	if len(result) == 0 && *job != "" {
+		return fmt.Errorf("run %d has no job matching %q", runID, *job)
+	}
+
+	if *output != outputTable {
+		return c.print(*output, table{value: result})
+	}
+
+	// Logs are printed as they are, a table of lines is hard to read
+	for i, log := range result {
+		if i > 0 {
+			fmt.Fprintln(c.stdout)
+		}
+		fmt.Fprintf(c.stdout, "==> %s <==\n", log.Job)
+		for _, line := range log.Lines {
+			fmt.Fprintln(c.stdout, line)
+		}
+	}
+	return nil
+}
+
+// waitRun follows the run until it completes, the state changes are printed to stderr.
+// previousAttempt is the attempt of a re-run run before it is re-run, 0 for a new run.
+// errRunFailed is returned if the run completes without success.
+func (c *CLI) waitRun(ctx context.Context, repository string, runID int64, previousAttempt int) (status string, conclusion string, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	watch, err := c.githubUseCase.WatchRun(ctx, gu.WatchRunInput{Repository: repository, WorkflowID: runID, PreviousAttempt: previousAttempt})
+	if err != nil {
+		return "", "", err
+	}
+
+	// The usecase skips the completed previous attempt of a re-run until the re-run starts
+	var lastErr error
+	for update := range watch.Updates {
+		if update.Err != nil {
+			lastErr = update.Err
+			continue
+		}
+
+		status, conclusion = update.Status, update.Conclusion
+		for _, transition := range update.Transitions {
+			var name = transition.JobName
+			if name == "" {
+				name = fmt.Sprintf("run %d", runID)
+			}
+			fmt.Fprintf(c.stderr, "%s: %s -> %s\n", name, transition.From, transition.To)
+		}
+	}
+
+	switch {
+	case status != "completed" && ctx.Err() != nil:
+		return status, conclusion, ctx.Err()
+	case status != "completed" && lastErr != nil:
+		return status, conclusion, lastErr
+	case status != "completed":
+		return status, conclusion, fmt.Errorf("run %d is not completed", runID)
+	case conclusion != "success":
+		return status, conclusion, fmt.Errorf("%w: run %d concluded with %s", errRunFailed, runID, conclusion)
+	}
+	return status, conclusion, nil
+}
+
+// defaultBranch returns the default branch of the repository
+func (c *CLI) defaultBranch(ctx context.Context, repository string) (string, error) {
+	getRepository, err := c.githubUseCase.GetRepository(ctx, gu.GetRepositoryInput{Repository: repository})
+	if err != nil {
+		return "", err
+	}
+	if getRepository.Repository.DefaultBranch == "" {
+		return "", fmt.Errorf("the default branch of %s is not found, give it with --ref", repository)
+	}
+	return getRepository.Repository.DefaultBranch, nil
+}
+
+// parseInputs reads the --input flags, like ["environment=production"]
+func parseInputs(inputs []string) (map[string]string, error) {
+	var values = make(map[string]string)
+	for _, input := range inputs {
+		key, value, ok := strings.Cut(input, "=")
+		if !ok || key == "" {
+			return nil, usageErrorf("invalid input %q, expected key=value", input)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// applyInputs fills the inputs of the workflow with their defaults and the given values.
+// The keys of the JSON inputs are given with their parent, like config.region.
+func applyInputs(workflow *pw.Pretty, values map[string]string) error {
+	var applied = make(map[string]bool)
+	apply := func(key string, defaultValue string, set func(string)) string {
+		value, ok := values[key]
+		if !ok {
+			set(defaultValue)
+			return defaultValue
+		}
+		applied[key] = true
+		set(value)
+		return value
+	}
+
+	for i := range workflow.Choices {
+		choice := &workflow.Choices[i]
+		value := apply(choice.Key, choice.Default, choice.SetValue)
+		if applied[choice.Key] && !slices.Contains(choice.Values, value) {
+			return usageErrorf("invalid value %q of input %s, expected one of %s", value, choice.Key, strings.Join(choice.Values, ", "))
+		}
+	}
+	for i := range workflow.Inputs {
+		apply(workflow.Inputs[i].Key, workflow.Inputs[i].Default, workflow.Inputs[i].SetValue)
+	}
+	for i := range workflow.Boolean {
+		boolean := &workflow.Boolean[i]
+		value := apply(boolean.Key, boolean.Default, boolean.SetValue)
+		if applied[boolean.Key] && value != "true" && value != "false" {
+			return usageErrorf("invalid value %q of input %s, expected true or false", value, boolean.Key)
+		}
+	}
+	for i := range workflow.KeyVals {
+		keyVal := &workflow.KeyVals[i]
+		var key = keyVal.Key
+		if keyVal.Parent != nil {
+			key = *keyVal.Parent + "." + keyVal.Key
+		}
+		apply(key, keyVal.Default, keyVal.SetValue)
+	}
+
+	for _, key := range sortedKeys(values) {
+		if !applied[key] {
+			return usageErrorf("the workflow has no input %s", key)
+		}
+	}
+	return nil
+}
+
+// parseRun reads the repository and the run id arguments
+func parseRun(arguments []string) (string, int64, error) {
+	runID, err := strconv.ParseInt(arguments[1], 10, 64)
+	if err != nil || runID <= 0 {
+		return "", 0, usageErrorf("invalid run id %q", arguments[1])
+	}
+	return arguments[0], runID, nil
+}
+
+// runState returns the conclusion of a completed run, its status otherwise
+func runState(status string, conclusion string) string {
+	if status == "completed" && conclusion != "" {
+		return conclusion
+	}
+	return status
+}
+
+func formatRunID(runID int64) string {
+	if runID == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(runID, 10)
+}
+
+func truncate(text string, length int) string {
+	if runes := []rune(text); len(runes) > length {
+		return string(runes[:length-1]) + "…"
+	}
+	return text
+}
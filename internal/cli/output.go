@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the --output flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// checkOutput fails for an unknown output format before any request is made
+func checkOutput(output string) error {
+	switch output {
+	case outputTable, outputJSON, outputYAML:
+		return nil
+	default:
+		return usageErrorf("unknown output %q, expected table, json or yaml", output)
+	}
+}
+
+// table is the output of a command, columns and rows are printed as a table, value as JSON or YAML
+type table struct {
+	columns []string
+	rows    [][]string
+	value   any
+}
+
+func (c *CLI) print(output string, t table) error {
+	switch output {
+	case outputJSON:
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(t.value)
+	case outputYAML:
+		encoder := yaml.NewEncoder(c.stdout)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(t.value); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return printTable(c.stdout, t.columns, t.rows)
+	}
+}
+
+func printTable(w io.Writer, columns []string, rows [][]string) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		// Tabs of the values would break the columns
+		for i, value := range row {
+			row[i] = strings.ReplaceAll(value, "\t", " ")
+		}
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is the lifetime of the JWTs of the app, GitHub accepts at most 10 minutes
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockSkew backdates the JWTs of the app, for the clocks that are ahead of GitHub
+	appJWTClockSkew = time.Minute
+
+	// installationTokenRefreshMargin is how long before its expiry an installation token is replaced
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// installationToken requests the installation tokens of a GitHub App with JWTs signed by its private key.
+// A token is kept until it is about to expire, installation tokens are valid for an hour.
+type installationToken struct {
+	client         HttpClient
+	apiURL         string
+	appID          int64
+	installationID int64
+	privateKeyPath string
+
+	mutex      sync.Mutex
+	privateKey *rsa.PrivateKey
+	token      string
+	expiresAt  time.Time
+	now        func() time.Time
+}
+
+func newInstallationToken(client HttpClient, apiURL string, appID int64, installationID int64, privateKeyPath string) *installationToken {
+	return &installationToken{
+		client:         client,
+		apiURL:         apiURL,
+		appID:          appID,
+		installationID: installationID,
+		privateKeyPath: privateKeyPath,
+		now:            time.Now,
+	}
+}
+
+// Token returns the current installation token, a new one is requested if it expires soon.
+// Concurrent requests wait for a single refresh.
+func (t *installationToken) Token(ctx context.Context) (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.token != "" && t.now().Add(installationTokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	token, expiresAt, err := t.refresh(ctx)
+	if err != nil {
+		return "", &TokenRefreshError{AppID: t.appID, InstallationID: t.installationID, Err: err}
+	}
+
+	t.token = token
+	t.expiresAt = expiresAt
+	return t.token, nil
+}
+
+// ExpiresAt returns when the current installation token expires, zero if none is requested yet
+func (t *installationToken) ExpiresAt() time.Time {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.expiresAt
+}
+
+// JWT returns a JWT of the app, the endpoints of the app itself like /app are authenticated with it
+func (t *installationToken) JWT() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	jwt, err := t.signJWT()
+	if err != nil {
+		return "", &TokenRefreshError{AppID: t.appID, InstallationID: t.installationID, Err: err}
+	}
+	return jwt, nil
+}
+
+func (t *installationToken) refresh(ctx context.Context) (string, time.Time, error) {
+	jwt, err := t.signJWT()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// Create an installation access token, the request is made with the client directly since do needs the token
+	path := fmt.Sprintf("%s/app/installations/%d/access_tokens", t.apiURL, t.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, newAPIError(resp, body, false)
+	}
+
+	var accessToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &accessToken); err != nil {
+		return "", time.Time{}, err
+	}
+	if accessToken.Token == "" {
+		return "", time.Time{}, errors.New("the response has no token")
+	}
+
+	return accessToken.Token, accessToken.ExpiresAt, nil
+}
+
+// signJWT signs a JWT of the app with RS256, the private key is read once
+func (t *installationToken) signJWT() (string, error) {
+	if t.privateKey == nil {
+		privateKey, err := readPrivateKey(t.privateKeyPath)
+		if err != nil {
+			return "", err
+		}
+		t.privateKey = privateKey
+	}
+
+	now := t.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(t.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// readPrivateKey reads the PEM private key of the app, GitHub generates PKCS #1 keys but PKCS #8 keys are accepted too
+func readPrivateKey(path string) (*rsa.PrivateKey, error) {
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, "~/") {
+		path = home + path[1:]
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the private key: %w", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("the private key %s is not PEM encoded", path)
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key is not an RSA key")
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_InstallationToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	}), 0o600))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/installations/42/access_tokens":
+			assert.Equal(t, http.MethodPost, r.Method)
+			claims := verifyJWT(t, &privateKey.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+			assert.Equal(t, "7", claims["iss"])
+			assert.Equal(t, float64(now.Add(-appJWTClockSkew).Unix()), claims["iat"])
+
+			refresh := refreshes.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`, refresh, now.Add(time.Hour).Format(time.RFC3339))
+		case "/user/repos":
+			fmt.Fprintf(w, `[{"full_name":%q}]`, r.Header.Get("Authorization"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+	repo.appToken = newInstallationToken(server.Client(), server.URL, 7, 42, keyPath)
+	repo.appToken.now = func() time.Time { return now }
+
+	authorization := func() string {
+		var repositories []GithubRepository
+		require.NoError(t, repo.do(context.Background(), nil, &repositories, requestOptions{
+			method: http.MethodGet,
+			path:   server.URL + "/user/repos",
+		}))
+		return repositories[0].FullName
+	}
+
+	assert.Equal(t, "Bearer ghs_1", authorization())
+	assert.Equal(t, "Bearer ghs_1", authorization(), "the token is kept until it expires soon")
+
+	now = now.Add(time.Hour - installationTokenRefreshMargin)
+	assert.Equal(t, "Bearer ghs_2", authorization(), "the token is refreshed before it expires")
+	assert.Equal(t, int32(2), refreshes.Load())
+}
+
+func TestRepo_InstallationTokenRefreshFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"A JSON web token could not be decoded"}`))
+	}))
+	defer server.Close()
+
+	t.Run("missing private key", func(t *testing.T) {
+		repo := &Repo{Client: server.Client(), apiURL: server.URL}
+		repo.appToken = newInstallationToken(server.Client(), server.URL, 7, 42, filepath.Join(t.TempDir(), "missing.pem"))
+
+		err := repo.do(context.Background(), nil, nil, requestOptions{method: http.MethodGet, path: server.URL + "/user/repos"})
+		assert.True(t, IsTokenRefreshFailed(err))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("rejected JWT", func(t *testing.T) {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err)
+
+		keyPath := filepath.Join(t.TempDir(), "app.pem")
+		require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), 0o600))
+
+		repo := &Repo{Client: server.Client(), apiURL: server.URL}
+		repo.appToken = newInstallationToken(server.Client(), server.URL, 7, 42, keyPath)
+
+		err = repo.do(context.Background(), nil, nil, requestOptions{method: http.MethodGet, path: server.URL + "/user/repos"})
+		assert.True(t, IsTokenRefreshFailed(err))
+		assert.True(t, IsUnauthorized(err))
+		assert.ErrorContains(t, err, "installation 42 of app 7")
+	})
+}
+
+// verifyJWT checks the RS256 signature of the JWT and returns its claims
+func verifyJWT(t *testing.T, publicKey *rsa.PublicKey, jwt string) map[string]any {
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
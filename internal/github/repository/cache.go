@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheMaxAge is the age after which unused cache entries are removed
+const cacheMaxAge = 7 * 24 * time.Hour
+
+// pruneOnce prunes the cache once per process, a client is created for every profile that is switched to
+var pruneOnce sync.Once
+
+// cachingClient is an HttpClient that keeps GET responses on disk and revalidates them with conditional requests.
+// GitHub does not count 304 Not Modified responses against the rate limit.
+type cachingClient struct {
+	client HttpClient
+	dir    string
+}
+
+type cacheEntry struct {
+	ETag         string      `json:"etag"`
+	LastModified string      `json:"last_modified"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// defaultCacheDir returns $XDG_CACHE_HOME/gama or its equivalent on the current OS
+func defaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(cacheDir, "gama"), nil
+}
+
+func newCachingClient(client HttpClient, dir string) (*cachingClient, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	c := &cachingClient{
+		client: client,
+		dir:    dir,
+	}
+	pruneOnce.Do(func() { go c.prune() })
+
+	return c, nil
+}
+
+func (c *cachingClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.client.Do(req)
+	}
+
+	key := c.key(req)
+	entry := c.load(key)
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serve the cached body, with the up-to-date headers like the rate limit ones
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		resp.Body.Close()
+
+		// Keep the entry from being pruned while it is in use
+		now := time.Now()
+		_ = os.Chtimes(filepath.Join(c.dir, key+".json"), now, now)
+
+		header := entry.Header.Clone()
+		for name, values := range resp.Header {
+			if name != "Content-Length" {
+				header[name] = values
+			}
+		}
+
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+			ContentLength: int64(len(entry.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	if !c.isCacheable(req, resp) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.store(key, &cacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Header:       resp.Header,
+		Body:         body,
+	})
+
+	return resp, nil
+}
+
+// isCacheable reports whether the response is a JSON response that can be revalidated.
+// The secrets and the variables are never written to the disk, the values of the variables are in plain text.
+func (c *cachingClient) isCacheable(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK || isSensitivePath(req.URL.Path) {
+		return false
+	}
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+	return strings.Contains(resp.Header.Get("Content-Type"), "json")
+}
+
+// isSensitivePath reports whether the path is one of the secrets or the variables of a repository, an organization
+// or an environment, like /repos/{owner}/{repo}/actions/variables or /repositories/{id}/environments/{name}/secrets
+func isSensitivePath(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "secrets" && segment != "variables" {
+			continue
+		}
+		if i > 0 && segments[i-1] == "actions" {
+			return true
+		}
+		if i > 1 && segments[i-2] == "environments" {
+			return true
+		}
+	}
+	return false
+}
+
+// key identifies a response by its URL, its media type and the token it is requested with
+func (c *cachingClient) key(req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Header.Get("Authorization")))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.Header.Get("Accept")))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.URL.String()))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *cachingClient) load(key string) *cacheEntry {
+	data, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+
+	return &entry
+}
+
+// store writes the entry to the cache, the cache is best effort so errors are ignored
+func (c *cachingClient) store(key string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first to not leave half written entries behind
+	file, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err != nil || closeErr != nil {
+		return
+	}
+
+	_ = os.Rename(file.Name(), filepath.Join(c.dir, key+".json"))
+}
+
+// prune removes the entries that are not used for cacheMaxAge
+func (c *cachingClient) prune() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) > cacheMaxAge {
+			_ = os.Remove(filepath.Join(c.dir, entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachingClient(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"name": "gama"}`))
+	}))
+	defer server.Close()
+
+	client, err := newCachingClient(server.Client(), t.TempDir())
+	assert.NoError(t, err)
+
+	repo := &Repo{Client: client, githubToken: "token"}
+
+	for i := 0; i < 3; i++ {
+		var response struct {
+			Name string `json:"name"`
+		}
+		err := repo.do(context.Background(), nil, &response, requestOptions{
+			method: http.MethodGet,
+			path:   server.URL + "/repos/termkit/gama",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "gama", response.Name)
+	}
+
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 2, notModified)
+	assert.Equal(t, 4000, repo.RateLimit().Remaining)
+
+	// Another token must not share the cached responses
+	otherRepo := &Repo{Client: client, githubToken: "other token"}
+	err = otherRepo.do(context.Background(), nil, nil, requestOptions{
+		method: http.MethodGet,
+		path:   server.URL + "/repos/termkit/gama",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, notModified)
+}
+
+func TestCachingClient_Sensitive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = w.Write([]byte(`{"variables": [{"name": "HOST", "value": "db.internal"}]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client, err := newCachingClient(server.Client(), dir)
+	assert.NoError(t, err)
+
+	repo := &Repo{Client: client, githubToken: "token"}
+	for _, path := range []string{
+		"/repos/termkit/gama/actions/variables",
+		"/repos/termkit/gama/actions/secrets",
+		"/orgs/termkit/actions/variables/HOST",
+		"/repositories/1/environments/production/variables",
+		"/repos/termkit/gama/environments/production/secrets/public-key",
+	} {
+		err := repo.do(context.Background(), nil, nil, requestOptions{
+			method: http.MethodGet,
+			path:   server.URL + path,
+		})
+		assert.NoError(t, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.False(t, isSensitivePath("/repos/termkit/secrets"))
+	assert.False(t, isSensitivePath("/repos/termkit/gama/actions/runs"))
+}
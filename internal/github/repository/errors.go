@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyLength is the number of characters kept from error bodies that are not JSON
+const maxErrorBodyLength = 200
+
+// APIError is returned for the responses of the GitHub API that are not successful
+type APIError struct {
+	StatusCode       int               // HTTP status code
+	Message          string            // error message of GitHub
+	DocumentationURL string            // documentation of the failed endpoint
+	RequestID        string            // X-GitHub-Request-Id, useful while contacting GitHub support
+	Errors           []ValidationError // details of validation failures
+	Scopes           []string          // scopes of the classic token of the request
+	AcceptedScopes   []string          // scopes the endpoint accepts, GitHub responds with 404 to tokens without them
+
+	rateLimited  bool // whether the request failed because of a primary or secondary rate limit
+	classicToken bool // whether the token of the request has OAuth scopes
+}
+
+// ValidationError is an item of the errors array of GitHub validation failures
+type ValidationError struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	var details []string
+	for _, validationError := range e.Errors {
+		if detail := validationError.String(); detail != "" && detail != e.Message {
+			details = append(details, detail)
+		}
+	}
+
+	if len(details) == 0 {
+		return e.Message
+	}
+
+	return e.Message + ": " + strings.Join(details, ", ")
+}
+
+func (v ValidationError) String() string {
+	if v.Message != "" {
+		return v.Message
+	}
+
+	if v.Field == "" {
+		return strings.TrimSpace(v.Resource + " " + v.Code)
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s is %s", v.Resource, v.Field, v.Code))
+}
+
+// UnmarshalJSON accepts the plain string items that some endpoints return in the errors array
+func (v *ValidationError) UnmarshalJSON(data []byte) error {
+	var message string
+	if err := json.Unmarshal(data, &message); err == nil {
+		v.Message = message
+		return nil
+	}
+
+	type shadow ValidationError
+	return json.Unmarshal(data, (*shadow)(v))
+}
+
+// newAPIError builds an APIError from a failed response and its body
+func newAPIError(resp *http.Response, body []byte, rateLimited bool) *APIError {
+	apiError := &APIError{
+		StatusCode:  resp.StatusCode,
+		RequestID:   resp.Header.Get("X-GitHub-Request-Id"),
+		rateLimited: rateLimited,
+	}
+	apiError.Scopes, apiError.classicToken = parseScopes(resp.Header, "X-OAuth-Scopes")
+	apiError.AcceptedScopes, _ = parseScopes(resp.Header, "X-Accepted-OAuth-Scopes")
+
+	var errorResponse struct {
+		Message          string            `json:"message"`
+		DocumentationURL string            `json:"documentation_url"`
+		Errors           []ValidationError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		apiError.Message = errorResponse.Message
+		apiError.DocumentationURL = errorResponse.DocumentationURL
+		apiError.Errors = errorResponse.Errors
+	} else {
+		// The body is not JSON, e.g. an HTML page of a proxy
+		apiError.Message = strings.TrimSpace(string(body))
+		if len(apiError.Message) > maxErrorBodyLength {
+			apiError.Message = apiError.Message[:maxErrorBodyLength] + "..."
+		}
+	}
+
+	if apiError.Message == "" {
+		apiError.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return apiError
+}
+
+// IsNotFound reports whether err is caused by a 404 Not Found response.
+// GitHub also responds with 404 to the requests that the token has no access to.
+func IsNotFound(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is caused by a missing, invalid or expired token
+func IsUnauthorized(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is caused by a token without the permission of the request, like the admin:org scope
+func IsForbidden(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusForbidden && !apiError.rateLimited
+}
+
+// IsRateLimited reports whether err is caused by a primary or secondary rate limit
+func IsRateLimited(err error) bool {
+	var apiError *APIError
+	return errors.As(err, &apiError) && (apiError.rateLimited || apiError.StatusCode == http.StatusTooManyRequests)
+}
+
+// MissingScopes returns the scopes the endpoint accepts if the classic token of the request has none of them.
+// Fine-grained tokens have permissions instead of scopes, nothing is returned for them.
+func (e *APIError) MissingScopes() []string {
+	if !e.classicToken {
+		return nil
+	}
+
+	for _, scope := range e.AcceptedScopes {
+		if HasScope(e.Scopes, scope) {
+			return nil
+		}
+	}
+	return e.AcceptedScopes
+}
+
+// TokenRefreshError is returned if the installation token of a GitHub App can not be requested,
+// like for a missing private key or an app that is not installed anymore
+type TokenRefreshError struct {
+	AppID          int64
+	InstallationID int64
+	Err            error
+}
+
+func (e *TokenRefreshError) Error() string {
+	return fmt.Sprintf("failed to refresh the token of installation %d of app %d: %v", e.InstallationID, e.AppID, e.Err)
+}
+
+func (e *TokenRefreshError) Unwrap() error {
+	return e.Err
+}
+
+// IsTokenRefreshFailed reports whether err is caused by a failure of requesting the installation token of a GitHub App
+func IsTokenRefreshFailed(err error) bool {
+	var tokenRefreshError *TokenRefreshError
+	return errors.As(err, &tokenRefreshError)
+}
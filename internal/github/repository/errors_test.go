@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAPIError(t *testing.T) {
+	t.Run("validation errors", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusUnprocessableEntity, Header: http.Header{}}
+		resp.Header.Set("X-GitHub-Request-Id", "0400:1D7B:2A1C3F")
+
+		apiError := newAPIError(resp, []byte(`{
+			"message": "Validation Failed",
+			"errors": [{"resource": "Issue", "field": "title", "code": "missing_field"}, "Input 'x' is not defined"],
+			"documentation_url": "https://docs.github.com/rest"
+		}`), false)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, apiError.StatusCode)
+		assert.Equal(t, "0400:1D7B:2A1C3F", apiError.RequestID)
+		assert.Equal(t, "https://docs.github.com/rest", apiError.DocumentationURL)
+		assert.Equal(t, "Validation Failed: Issue title is missing_field, Input 'x' is not defined", apiError.Error())
+	})
+
+	t.Run("body is not json", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+
+		apiError := newAPIError(resp, []byte("<html>Bad Gateway</html>"), false)
+		assert.Equal(t, "<html>Bad Gateway</html>", apiError.Error())
+
+		apiError = newAPIError(resp, nil, false)
+		assert.Equal(t, "Bad Gateway", apiError.Error())
+	})
+}
+
+func TestIsAPIError(t *testing.T) {
+	notFound := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusNotFound})
+	assert.True(t, IsNotFound(notFound))
+	assert.False(t, IsUnauthorized(notFound))
+
+	assert.True(t, IsUnauthorized(&APIError{StatusCode: http.StatusUnauthorized}))
+	assert.True(t, IsRateLimited(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsRateLimited(&APIError{StatusCode: http.StatusForbidden, rateLimited: true}))
+	assert.False(t, IsRateLimited(&APIError{StatusCode: http.StatusForbidden}))
+	assert.True(t, IsForbidden(&APIError{StatusCode: http.StatusForbidden}))
+	assert.False(t, IsForbidden(&APIError{StatusCode: http.StatusForbidden, rateLimited: true}))
+	assert.False(t, IsNotFound(context.Canceled))
+}
+
+func TestRepo_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-Request-Id", "request-id")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found", "documentation_url": "https://docs.github.com/rest"}`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	_, err := repo.GetRepository(context.Background(), "termkit/missing")
+	assert.True(t, IsNotFound(err))
+	assert.EqualError(t, err, "Not Found")
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// maxPerPage is the largest page size accepted by the GitHub REST API
+const maxPerPage = 100
+
+// paginate requests a list endpoint and follows the Link rel="next" headers until every page is read,
+// maxItems items are collected or the context is done. A maxItems of 0 collects every item.
+// items extracts the list of items from a decoded page.
+func paginate[P any, T any](ctx context.Context, r *Repo, requestOptions requestOptions, maxItems int, items func(page P) []T) ([]T, error) {
+	perPage := maxPerPage
+	if maxItems > 0 && maxItems < perPage {
+		perPage = maxItems
+	}
+
+	// Copy query parameters to not modify the caller's map
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+	}
+	for key, value := range requestOptions.queryParams {
+		queryParams[key] = value
+	}
+	requestOptions.queryParams = queryParams
+
+	var result []T
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var page P
+		header, err := r.doWithHeader(ctx, nil, &page, requestOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, items(page)...)
+		if maxItems > 0 && len(result) >= maxItems {
+			return result[:maxItems], nil
+		}
+
+		nextURL := nextPageURL(header.Get("Link"))
+		if nextURL == "" {
+			return result, nil
+		}
+
+		// The next page URL already contains every query parameter
+		requestOptions.path = nextURL
+		requestOptions.queryParams = nil
+	}
+}
+
+// nextPageURL returns the URL of the rel="next" link in a Link header, or an empty string if there is none.
+//
+//	Link: <https://api.github.com/user/repos?page=2>; rel="next", <https://api.github.com/user/repos?page=5>; rel="last"
+func nextPageURL(linkHeader string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		segments := strings.Split(link, ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		linkURL := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(linkURL, "<") || !strings.HasSuffix(linkURL, ">") {
+			continue
+		}
+
+		for _, param := range segments[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && key == "rel" && strings.Trim(value, `"`) == "next" {
+				return strings.TrimSuffix(strings.TrimPrefix(linkURL, "<"), ">")
+			}
+		}
+	}
+
+	return ""
+}
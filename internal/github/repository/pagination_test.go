@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextPageURL(t *testing.T) {
+	var header = `<https://api.github.com/user/repos?page=2&per_page=100>; rel="next", <https://api.github.com/user/repos?page=7&per_page=100>; rel="last"`
+	assert.Equal(t, "https://api.github.com/user/repos?page=2&per_page=100", nextPageURL(header))
+
+	header = `<https://api.github.com/user/repos?page=1>; rel="prev", <https://api.github.com/user/repos?page=1>; rel="first"`
+	assert.Equal(t, "", nextPageURL(header))
+
+	assert.Equal(t, "", nextPageURL(""))
+}
+
+// newPaginatedServer serves totalItems integers, perPage items per page, linking the pages with Link headers
+func newPaginatedServer(totalItems int) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		var items []int
+		for i := (page - 1) * perPage; i < min(page*perPage, totalItems); i++ {
+			items = append(items, i)
+		}
+
+		if page*perPage < totalItems {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=%d&per_page=%d>; rel="next"`, server.URL, page+1, perPage))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(items)
+	}))
+	return server
+}
+
+func TestPaginate(t *testing.T) {
+	server := newPaginatedServer(250)
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client()}
+	identity := func(page []int) []int { return page }
+
+	t.Run("reads every page", func(t *testing.T) {
+		items, err := paginate(context.Background(), repo, requestOptions{
+			method: http.MethodGet,
+			path:   server.URL + "/items",
+		}, 0, identity)
+		assert.NoError(t, err)
+		assert.Len(t, items, 250)
+		assert.Equal(t, 249, items[249])
+	})
+
+	t.Run("stops at max items", func(t *testing.T) {
+		items, err := paginate(context.Background(), repo, requestOptions{
+			method: http.MethodGet,
+			path:   server.URL + "/items",
+		}, 130, identity)
+		assert.NoError(t, err)
+		assert.Len(t, items, 130)
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := paginate(ctx, repo, requestOptions{
+			method: http.MethodGet,
+			path:   server.URL + "/items",
+		}, 0, identity)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -2,19 +2,58 @@ package repository
 
 import (
 	"context"
+	"io"
+	"time"
 )
 
 type Repository interface {
+	WebURL() string
+	RateLimit() *RateLimit
+	GetRateLimit(ctx context.Context) (*RateLimit, error)
+	GetAuthenticatedUser(ctx context.Context) (*GithubUser, error)
+	GetTokenInfo(ctx context.Context) (*TokenInfo, error)
 	TestConnection(ctx context.Context) error
-	ListRepositories(ctx context.Context, limit int) ([]GithubRepository, error)
+	ListRepositories(ctx context.Context, filter RepositoriesFilter, limit int) ([]GithubRepository, error)
+	ListOrganizationRepositories(ctx context.Context, organization string, limit int) ([]GithubRepository, error)
 	GetRepository(ctx context.Context, repository string) (*GithubRepository, error)
 	ListBranches(ctx context.Context, repository string) ([]GithubBranch, error)
-	ListWorkflowRuns(ctx context.Context, repository string, branch string) (*WorkflowRuns, error)
-	TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, workflow any) error
+	ListWorkflowRuns(ctx context.Context, repository string, filter WorkflowRunsFilter, limit int) (*WorkflowRuns, error)
+	GetWorkflowRun(ctx context.Context, repository string, runId int64) (*WorkflowRun, error)
+	ListJobsForRun(ctx context.Context, repository string, runId int64) ([]WorkflowJob, error)
+	GetJob(ctx context.Context, repository string, jobId int64) (*WorkflowJob, error)
+	TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, workflow any) (time.Time, error)
 	GetWorkflows(ctx context.Context, repository string) ([]Workflow, error)
-	GetTriggerableWorkflows(ctx context.Context, repository string) ([]Workflow, error)
+	GetTriggerableWorkflows(ctx context.Context, repository string, branch string) ([]Workflow, error)
+	GetWorkflowEvents(ctx context.Context, repository string, branch string, workflowPath string) ([]string, error)
+	EnableWorkflow(ctx context.Context, repository string, workflowId int64) error
+	DisableWorkflow(ctx context.Context, repository string, workflowId int64) error
 	InspectWorkflowContent(ctx context.Context, repository string, branch string, workflowFile string) ([]byte, error)
-	GetWorkflowRunLogs(ctx context.Context, repository string, runId int64) (GithubWorkflowRunLogs, error)
+	GetWorkflowRunLogs(ctx context.Context, repository string, runId int64) (io.ReadCloser, error)
+	ListArtifacts(ctx context.Context, repository string, runId int64) ([]Artifact, error)
+	DownloadArtifact(ctx context.Context, repository string, artifactId int64) (io.ReadCloser, error)
+	DeleteArtifact(ctx context.Context, repository string, artifactId int64) error
+	ListCaches(ctx context.Context, repository string, limit int) ([]Cache, error)
+	GetCacheUsage(ctx context.Context, repository string) (*CacheUsage, error)
+	DeleteCache(ctx context.Context, repository string, cacheId int64) error
+	DeleteCachesByKey(ctx context.Context, repository string, key string, ref string) ([]Cache, error)
+	ListRunners(ctx context.Context, repository string) ([]Runner, error)
+	ListOrganizationRunners(ctx context.Context, organization string) ([]Runner, error)
+	ListRunnerGroups(ctx context.Context, organization string) ([]RunnerGroup, error)
+	ListRunnerGroupRunners(ctx context.Context, organization string, groupId int64) ([]Runner, error)
+	ListEnvironments(ctx context.Context, repository string) ([]DeploymentEnvironment, error)
+	ListVariables(ctx context.Context, repository string, environment string) ([]Variable, error)
+	CreateVariable(ctx context.Context, repository string, environment string, variable Variable) error
+	UpdateVariable(ctx context.Context, repository string, environment string, variable Variable) error
+	DeleteVariable(ctx context.Context, repository string, environment string, name string) error
+	ListSecrets(ctx context.Context, repository string, environment string) ([]Secret, error)
+	GetSecretsPublicKey(ctx context.Context, repository string, environment string) (*SecretsPublicKey, error)
+	PutSecret(ctx context.Context, repository string, environment string, name string, secret EncryptedSecret) error
+	DeleteSecret(ctx context.Context, repository string, environment string, name string) error
+	GetWorkflowRunTiming(ctx context.Context, repository string, runId int64) (*RunTiming, error)
+	GetWorkflowTiming(ctx context.Context, repository string, workflowId int64) (*WorkflowTiming, error)
+	GetPendingDeployments(ctx context.Context, repository string, runId int64) ([]PendingDeployment, error)
+	ReviewPendingDeployments(ctx context.Context, repository string, runId int64, review DeploymentReview) error
+	GetJobLogs(ctx context.Context, repository string, jobId int64) (string, error)
 	ReRunFailedJobs(ctx context.Context, repository string, runId int64) error
 	ReRunWorkflow(ctx context.Context, repository string, runId int64) error
 	CancelWorkflow(ctx context.Context, repository string, runId int64) error
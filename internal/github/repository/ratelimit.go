@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxRateLimitRetries is the number of times a rate limited request is retried
+	maxRateLimitRetries = 3
+
+	// maxRateLimitWait is the longest wait before a retry, the rate limit error is returned instead of longer waits
+	maxRateLimitWait = 2 * time.Minute
+
+	// secondaryRateLimitWait is the first wait for secondary rate limits that have no Retry-After header
+	secondaryRateLimitWait = time.Minute
+)
+
+type RateLimit struct {
+	Limit     int       // maximum number of requests per hour
+	Remaining int       // number of requests remaining in the current window
+	Used      int       // number of requests made in the current window
+	Reset     time.Time // time at which the current window resets
+	Resource  string    // rate limit resource, like core, search, graphql
+}
+
+// parseRateLimit reads the X-RateLimit-* headers, it returns false if the response has none of them.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	remaining, _ := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	return RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Unix(reset, 0),
+		Resource:  header.Get("X-RateLimit-Resource"),
+	}, true
+}
+
+// rateLimitDelay reports whether a failed response is caused by a primary or a secondary rate limit,
+// and how long to wait before retrying it. attempt is the number of retries made so far.
+func rateLimitDelay(statusCode int, header http.Header, body []byte, attempt int, now time.Time) (time.Duration, bool) {
+	if statusCode != http.StatusForbidden && statusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	// Retry-After has the number of seconds to wait
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	// The primary rate limit is exhausted until the reset time
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Unix(reset, 0).Sub(now), 0) + time.Second, true
+		}
+	}
+
+	// Secondary rate limits may come without headers, wait longer on every attempt
+	if statusCode == http.StatusTooManyRequests || bytes.Contains(bytes.ToLower(body), []byte("secondary rate limit")) {
+		return secondaryRateLimitWait << attempt, true
+	}
+
+	return 0, false
+}
+
+// updateRateLimit stores the core rate limit of a response
+func (r *Repo) updateRateLimit(header http.Header) {
+	rateLimit, ok := parseRateLimit(header)
+	if !ok || (rateLimit.Resource != "" && rateLimit.Resource != "core") {
+		return
+	}
+
+	r.rateLimitMutex.Lock()
+	defer r.rateLimitMutex.Unlock()
+	r.rateLimit = &rateLimit
+}
+
+// RateLimit returns the core rate limit of the last response, or nil if no response is received yet.
+func (r *Repo) RateLimit() *RateLimit {
+	r.rateLimitMutex.RLock()
+	defer r.rateLimitMutex.RUnlock()
+
+	if r.rateLimit == nil {
+		return nil
+	}
+	rateLimit := *r.rateLimit
+	return &rateLimit
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "5000")
+	header.Set("X-RateLimit-Remaining", "4990")
+	header.Set("X-RateLimit-Used", "10")
+	header.Set("X-RateLimit-Reset", "1700000000")
+	header.Set("X-RateLimit-Resource", "core")
+
+	rateLimit, ok := parseRateLimit(header)
+	assert.True(t, ok)
+	assert.Equal(t, RateLimit{
+		Limit:     5000,
+		Remaining: 4990,
+		Used:      10,
+		Reset:     time.Unix(1700000000, 0),
+		Resource:  "core",
+	}, rateLimit)
+
+	_, ok = parseRateLimit(http.Header{})
+	assert.False(t, ok)
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("retry after", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Retry-After", "30")
+
+		delay, ok := rateLimitDelay(http.StatusForbidden, header, nil, 0, now)
+		assert.True(t, ok)
+		assert.Equal(t, 30*time.Second, delay)
+	})
+
+	t.Run("primary rate limit", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Second).Unix(), 10))
+
+		delay, ok := rateLimitDelay(http.StatusForbidden, header, nil, 0, now)
+		assert.True(t, ok)
+		assert.Equal(t, 11*time.Second, delay)
+	})
+
+	t.Run("secondary rate limit", func(t *testing.T) {
+		body := []byte(`{"message": "You have exceeded a secondary rate limit."}`)
+
+		delay, ok := rateLimitDelay(http.StatusForbidden, http.Header{}, body, 1, now)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Minute, delay)
+	})
+
+	t.Run("not rate limited", func(t *testing.T) {
+		_, ok := rateLimitDelay(http.StatusForbidden, http.Header{}, []byte(`{"message": "Resource not accessible"}`), 0, now)
+		assert.False(t, ok)
+
+		_, ok = rateLimitDelay(http.StatusNotFound, http.Header{}, nil, 0, now)
+		assert.False(t, ok)
+	})
+}
+
+func TestRepo_RetryRateLimitedRequest(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Reset", "1700000000")
+		if requests == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		_, _ = w.Write([]byte(`{"name": "gama"}`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client()}
+
+	var response struct {
+		Name string `json:"name"`
+	}
+	err := repo.do(context.Background(), nil, &response, requestOptions{
+		method: http.MethodGet,
+		path:   server.URL,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "gama", response.Name)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 4999, repo.RateLimit().Remaining)
+}
@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	pkgconfig "github.com/termkit/gama/pkg/config"
@@ -20,18 +22,123 @@ import (
 type Repo struct {
 	Client HttpClient
 
+	// downloadClient streams large downloads without the timeout of Client, Client is used if it is nil
+	downloadClient HttpClient
+
 	githubToken string
-}
+	appToken    *installationToken // installation tokens of a GitHub App, used instead of githubToken if it is set
+	apiURL      string             // REST API base URL, e.g. https://api.github.com or https://HOSTNAME/api/v3
+	webURL      string             // web base URL, e.g. https://github.com or https://HOSTNAME
 
-var githubAPIURL = "https://api.github.com"
+	rateLimitMutex sync.RWMutex
+	rateLimit      *RateLimit
+}
 
 func New(cfg *pkgconfig.Config) *Repo {
-	return &Repo{
-		Client: &http.Client{
-			Timeout: 20 * time.Second,
-		},
-		githubToken: cfg.Github.Token,
+	var client HttpClient = &http.Client{
+		Timeout: 20 * time.Second,
+	}
+
+	// Revalidate responses from the on-disk cache if the cache directory is usable
+	if cacheDir, err := defaultCacheDir(); err == nil {
+		if cachingClient, err := newCachingClient(client, cacheDir); err == nil {
+			client = cachingClient
+		}
 	}
+
+	repo := &Repo{
+		Client:         client,
+		downloadClient: &http.Client{},
+		githubToken:    cfg.Github.Token,
+		apiURL:         cfg.Github.APIURL,
+		webURL:         cfg.Github.WebURL,
+	}
+
+	// A GitHub App authenticates with installation tokens that it requests itself
+	if app := cfg.Github.App; app.IsSet() {
+		repo.appToken = newInstallationToken(client, cfg.Github.APIURL, app.ID, app.InstallationID, app.PrivateKey)
+	}
+
+	return repo
+}
+
+func (r *Repo) WebURL() string {
+	return r.webURL
+}
+
+func (r *Repo) GetAuthenticatedUser(ctx context.Context) (*GithubUser, error) {
+	if r.appToken != nil {
+		return r.getAppUser(ctx)
+	}
+
+	// Get the user that the token belongs to
+	var user GithubUser
+	err := r.do(ctx, nil, &user, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/user",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *Repo) GetTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	if r.appToken != nil {
+		user, err := r.getAppUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &TokenInfo{User: *user, App: true, ExpiresAt: r.appToken.ExpiresAt()}, nil
+	}
+
+	// Get the user that the token belongs to, the headers of the response describe the token
+	var user GithubUser
+	header, err := r.doWithHeader(ctx, nil, &user, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/user",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, isClassic := parseScopes(header, "X-OAuth-Scopes")
+	acceptedScopes, _ := parseScopes(header, "X-Accepted-OAuth-Scopes")
+	return &TokenInfo{
+		User:           user,
+		Scopes:         scopes,
+		AcceptedScopes: acceptedScopes,
+		Classic:        isClassic,
+		ExpiresAt:      parseTokenExpiration(header),
+	}, nil
+}
+
+// getAppUser returns the bot user of the GitHub App, the actor of the runs it triggers
+func (r *Repo) getAppUser(ctx context.Context) (*GithubUser, error) {
+	// Get the app with its JWT, installation tokens have no user
+	var app struct {
+		ID   int64  `json:"id"`
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	}
+	err := r.do(ctx, nil, &app, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/app",
+		contentType: "application/json",
+		asApp:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GithubUser{
+		ID:    app.ID,
+		Login: app.Slug + "[bot]",
+		Name:  app.Name,
+	}, nil
 }
 
 func (r *Repo) TestConnection(ctx context.Context) error {
@@ -39,7 +146,7 @@ func (r *Repo) TestConnection(ctx context.Context) error {
 	var repositories []GithubRepository
 	err := r.do(ctx, nil, &repositories, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/user/repos",
+		path:        r.apiURL + "/user/repos",
 		contentType: "application/json",
 		queryParams: map[string]string{
 			"visibility": "all",
@@ -52,20 +159,94 @@ func (r *Repo) TestConnection(ctx context.Context) error {
 	return nil
 }
 
-func (r *Repo) ListRepositories(ctx context.Context, limit int) ([]GithubRepository, error) {
-	if limit == 0 {
-		limit = 200
+func (r *Repo) GetRateLimit(ctx context.Context) (*RateLimit, error) {
+	// Get the rate limit status, this request does not count against the rate limit
+	var rateLimit struct {
+		Resources struct {
+			Core struct {
+				Limit     int   `json:"limit"`
+				Remaining int   `json:"remaining"`
+				Used      int   `json:"used"`
+				Reset     int64 `json:"reset"`
+			} `json:"core"`
+		} `json:"resources"`
+	}
+	err := r.do(ctx, nil, &rateLimit, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/rate_limit",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	var repositories []GithubRepository
-	err := r.do(ctx, nil, &repositories, requestOptions{
+	core := rateLimit.Resources.Core
+	return &RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Used:      core.Used,
+		Reset:     time.Unix(core.Reset, 0),
+		Resource:  "core",
+	}, nil
+}
+
+func (r *Repo) ListRepositories(ctx context.Context, filter RepositoriesFilter, limit int) ([]GithubRepository, error) {
+	if r.appToken != nil {
+		return r.listInstallationRepositories(ctx, limit)
+	}
+
+	// List repositories for the authenticated user, a limit of 0 lists all of them
+	var queryParams = make(map[string]string)
+	if filter.Affiliation != "" {
+		queryParams["affiliation"] = filter.Affiliation
+	}
+	if filter.Visibility != "" {
+		queryParams["visibility"] = filter.Visibility
+	}
+
+	repositories, err := paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/user/repos",
+		contentType: "application/json",
+		queryParams: queryParams,
+	}, limit, func(page []GithubRepository) []GithubRepository {
+		return page
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories, nil
+}
+
+// listInstallationRepositories lists the repositories the GitHub App is installed on, a limit of 0 lists all of them
+func (r *Repo) listInstallationRepositories(ctx context.Context, limit int) ([]GithubRepository, error) {
+	// List the repositories of the installation, the affiliation and visibility filters are only for users
+	repositories, err := paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/installation/repositories",
+		contentType: "application/json",
+	}, limit, func(page installationRepositories) []GithubRepository {
+		return page.Repositories
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repositories, nil
+}
+
+func (r *Repo) ListOrganizationRepositories(ctx context.Context, organization string, limit int) ([]GithubRepository, error) {
+	// List the repositories of an organization the authenticated user can see, a limit of 0 lists all of them
+	repositories, err := paginate(ctx, r, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/user/repos",
+		path:        r.apiURL + "/orgs/" + organization + "/repos",
 		contentType: "application/json",
 		queryParams: map[string]string{
-			"visibility": "private",
-			"per_page":   strconv.Itoa(limit),
+			"type": "all",
 		},
+	}, limit, func(page []GithubRepository) []GithubRepository {
+		return page
 	})
 	if err != nil {
 		return nil, err
@@ -75,25 +256,26 @@ func (r *Repo) ListRepositories(ctx context.Context, limit int) ([]GithubReposit
 }
 
 func (r *Repo) ListBranches(ctx context.Context, repository string) ([]GithubBranch, error) {
-	// List branches for the given repository
-	var branches any
-	err := r.do(ctx, nil, &branches, requestOptions{
+	// List all branches for the given repository
+	branches, err := paginate(ctx, r, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/branches",
+		path:        r.apiURL + "/repos/" + repository + "/branches",
 		contentType: "application/json",
+	}, 0, func(page []GithubBranch) []GithubBranch {
+		return page
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return []GithubBranch{}, nil
+	return branches, nil
 }
 
 func (r *Repo) GetRepository(ctx context.Context, repository string) (*GithubRepository, error) {
 	var repo GithubRepository
 	err := r.do(ctx, nil, &repo, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository,
+		path:        r.apiURL + "/repos/" + repository,
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -103,90 +285,180 @@ func (r *Repo) GetRepository(ctx context.Context, repository string) (*GithubRep
 	return &repo, nil
 }
 
-func (r *Repo) ListWorkflowRuns(ctx context.Context, repository string, branch string) (*WorkflowRuns, error) {
-	// List workflow runs for the given repository and branch
-	var workflowRuns WorkflowRuns
-	err := r.do(ctx, nil, &workflowRuns, requestOptions{
+func (r *Repo) ListWorkflowRuns(ctx context.Context, repository string, filter WorkflowRunsFilter, limit int) (*WorkflowRuns, error) {
+	// List workflow runs for the given repository that match the filter, a limit of 0 lists all of them
+	var runsPath = "/repos/" + repository + "/actions/runs"
+	if filter.Workflow != "" {
+		runsPath = "/repos/" + repository + "/actions/workflows/" + path.Base(filter.Workflow) + "/runs"
+	}
+
+	var totalCount int64
+	workflowRuns, err := paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + runsPath,
+		contentType: "application/json",
+		queryParams: filter.queryParams(),
+	}, limit, func(page WorkflowRuns) []WorkflowRun {
+		totalCount = page.TotalCount
+		return page.WorkflowRuns
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkflowRuns{
+		TotalCount:   totalCount,
+		WorkflowRuns: workflowRuns,
+	}, nil
+}
+
+func (r *Repo) ListJobsForRun(ctx context.Context, repository string, runId int64) ([]WorkflowJob, error) {
+	// List the jobs of the latest attempt of the given workflow run
+	jobs, err := paginate(ctx, r, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/jobs",
 		contentType: "application/json",
 		queryParams: map[string]string{
-			"branch": branch,
+			"filter": "latest",
 		},
+	}, 0, func(page WorkflowJobs) []WorkflowJob {
+		return page.Jobs
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &workflowRuns, nil
+	return jobs, nil
 }
 
-func (r *Repo) TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, workflow any) error {
+// queryParams returns the query parameters of the list workflow runs endpoints for the filter
+func (f WorkflowRunsFilter) queryParams() map[string]string {
+	var queryParams = make(map[string]string)
+	if f.Branch != "" {
+		queryParams["branch"] = f.Branch
+	}
+	if f.Event != "" {
+		queryParams["event"] = f.Event
+	}
+	if f.Actor != "" {
+		queryParams["actor"] = f.Actor
+	}
+	if f.Status != "" {
+		queryParams["status"] = f.Status
+	}
+	if f.HeadSHA != "" {
+		queryParams["head_sha"] = f.HeadSHA
+	}
+	if f.ExcludePullRequests {
+		queryParams["exclude_pull_requests"] = "true"
+	}
+
+	// The created parameter takes a date range in the GitHub search syntax
+	const timeFormat = time.RFC3339
+	switch {
+	case !f.CreatedAfter.IsZero() && !f.CreatedBefore.IsZero():
+		queryParams["created"] = f.CreatedAfter.UTC().Format(timeFormat) + ".." + f.CreatedBefore.UTC().Format(timeFormat)
+	case !f.CreatedAfter.IsZero():
+		queryParams["created"] = ">=" + f.CreatedAfter.UTC().Format(timeFormat)
+	case !f.CreatedBefore.IsZero():
+		queryParams["created"] = "<=" + f.CreatedBefore.UTC().Format(timeFormat)
+	}
+
+	return queryParams
+}
+
+func (r *Repo) GetWorkflowRun(ctx context.Context, repository string, runId int64) (*WorkflowRun, error) {
+	// Get a workflow run
+	var workflowRun WorkflowRun
+	err := r.do(ctx, nil, &workflowRun, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflowRun, nil
+}
+
+func (r *Repo) GetJob(ctx context.Context, repository string, jobId int64) (*WorkflowJob, error) {
+	// Get a job of a workflow run
+	var job WorkflowJob
+	err := r.do(ctx, nil, &job, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/jobs/" + strconv.FormatInt(jobId, 10),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *Repo) TriggerWorkflow(ctx context.Context, repository string, branch string, workflowName string, workflow any) (time.Time, error) {
 	var payload = fmt.Sprintf(`{"ref": "%s", "inputs": %s}`, branch, workflow)
 
 	// Trigger a workflow for the given repository and branch
-	err := r.do(ctx, payload, nil, requestOptions{
+	header, err := r.doWithHeader(ctx, payload, nil, requestOptions{
 		method: http.MethodPost,
-		path:   githubAPIURL + "/repos/" + repository + "/actions/workflows/" + path.Base(workflowName) + "/dispatches",
+		path:   r.apiURL + "/repos/" + repository + "/actions/workflows/" + path.Base(workflowName) + "/dispatches",
 		accept: "application/vnd.github+json",
 	})
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
-	return nil
+	// The time of GitHub is compared with the creation times of the runs, the local clock may be off
+	dispatchedAt, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return time.Now(), nil
+	}
+
+	return dispatchedAt, nil
 }
 
 func (r *Repo) GetWorkflows(ctx context.Context, repository string) ([]Workflow, error) {
-	// Get a workflow run for the given repository and runId
-	var githubWorkflow githubWorkflow
-	err := r.do(ctx, nil, &githubWorkflow, requestOptions{
+	// Get all workflows for the given repository
+	workflows, err := paginate(ctx, r, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/workflows",
+		path:        r.apiURL + "/repos/" + repository + "/actions/workflows",
 		contentType: "application/json",
+	}, 0, func(page githubWorkflow) []Workflow {
+		return page.Workflows
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var workflows []Workflow
-	for _, workflow := range githubWorkflow.Workflows {
-		workflows = append(workflows, workflow)
-	}
-
 	return workflows, nil
 }
 
-func (r *Repo) GetTriggerableWorkflows(ctx context.Context, repository string) ([]Workflow, error) {
-	// Get a workflow run for the given repository and runId
-	var workflows githubWorkflow
-	err := r.do(ctx, nil, &workflows, requestOptions{
-		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/workflows",
-		contentType: "application/json",
-	})
+func (r *Repo) GetTriggerableWorkflows(ctx context.Context, repository string, branch string) ([]Workflow, error) {
+	workflows, err := r.GetWorkflows(ctx, repository)
 	if err != nil {
 		return nil, err
 	}
 
 	// Filter workflows to only include those that are dispatchable and manually triggerable
 	var triggerableWorkflows []Workflow
-	for _, workflow := range workflows.Workflows {
-		// Get the workflow file content
-		fileContent, err := r.getWorkflowFile(ctx, repository, workflow.Path)
-		if err != nil {
+	for _, workflow := range workflows {
+		// Get the workflow file content, the workflow may not exist on the given branch
+		fileContent, err := r.getWorkflowFile(ctx, repository, branch, workflow.Path)
+		if IsNotFound(err) {
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
-		// Parse the workflow file content as YAML
-		var wfFile workflowFile
-		err = yaml.Unmarshal([]byte(fileContent), &wfFile)
+		events, err := parseWorkflowEvents(fileContent)
 		if err != nil {
 			return nil, err
 		}
 
-		// Check if the workflow file content has a "workflow_dispatch" key
-		if _, ok := wfFile.On["workflow_dispatch"]; ok {
+		// Check if the workflow can be triggered by "workflow_dispatch"
+		if slices.Contains(events, "workflow_dispatch") {
 			triggerableWorkflows = append(triggerableWorkflows, workflow)
 		}
 	}
@@ -194,12 +466,50 @@ func (r *Repo) GetTriggerableWorkflows(ctx context.Context, repository string) (
 	return triggerableWorkflows, nil
 }
 
+func (r *Repo) GetWorkflowEvents(ctx context.Context, repository string, branch string, workflowPath string) ([]string, error) {
+	// Read the events that trigger the workflow from the "on" key of its file
+	fileContent, err := r.getWorkflowFile(ctx, repository, branch, workflowPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseWorkflowEvents(fileContent)
+}
+
+func (r *Repo) EnableWorkflow(ctx context.Context, repository string, workflowId int64) error {
+	// Enable a workflow that is disabled manually or for inactivity
+	err := r.do(ctx, nil, nil, requestOptions{
+		method:      http.MethodPut,
+		path:        r.apiURL + "/repos/" + repository + "/actions/workflows/" + strconv.FormatInt(workflowId, 10) + "/enable",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DisableWorkflow(ctx context.Context, repository string, workflowId int64) error {
+	// Disable a workflow, it is not triggered by any event until it is enabled
+	err := r.do(ctx, nil, nil, requestOptions{
+		method:      http.MethodPut,
+		path:        r.apiURL + "/repos/" + repository + "/actions/workflows/" + strconv.FormatInt(workflowId, 10) + "/disable",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *Repo) InspectWorkflowContent(ctx context.Context, repository string, branch string, workflowFile string) ([]byte, error) {
 	// Get the content of the workflow file
 	var githubFile githubFile
 	err := r.do(ctx, nil, &githubFile, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/contents/" + workflowFile,
+		path:        r.apiURL + "/repos/" + repository + "/contents/" + workflowFile,
 		contentType: "application/vnd.github.VERSION.raw",
 		queryParams: map[string]string{
 			"ref": branch,
@@ -218,28 +528,20 @@ func (r *Repo) InspectWorkflowContent(ctx context.Context, repository string, br
 	return decodedContent, nil
 }
 
-//func (r *Repo) GetWorkflowRun(ctx context.Context, repository string, runId int64) (GithubWorkflowRun, error) {
-//	// Get a workflow run for the given repository and runId
-//	var workflowRun GithubWorkflowRun
-//	err := r.do(ctx, nil, &workflowRun, requestOptions{
-//		method:      http.MethodGet,
-//		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10),
-//		contentType: "application/json",
-//	})
-//	if err != nil {
-//		return GithubWorkflowRun{}, err
-//	}
-//
-//	return workflowRun, nil
-//}
-
-func (r *Repo) getWorkflowFile(ctx context.Context, repository string, path string) (string, error) {
+func (r *Repo) getWorkflowFile(ctx context.Context, repository string, branch string, path string) (string, error) {
+	// Read the file from the given branch, or from the default branch if it is empty
+	queryParams := map[string]string{}
+	if branch != "" {
+		queryParams["ref"] = branch
+	}
+
 	// Get the content of the workflow file
 	var githubFile githubFile
 	err := r.do(ctx, nil, &githubFile, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/contents/" + path,
+		path:        r.apiURL + "/repos/" + repository + "/contents/" + path,
 		contentType: "application/vnd.github.VERSION.raw",
+		queryParams: queryParams,
 	})
 	if err != nil {
 		return "", err
@@ -254,26 +556,374 @@ func (r *Repo) getWorkflowFile(ctx context.Context, repository string, path stri
 	return string(decodedContent), nil
 }
 
-func (r *Repo) GetWorkflowRunLogs(ctx context.Context, repository string, runId int64) (GithubWorkflowRunLogs, error) {
-	// Get the logs for a given workflow run
-	var workflowRunLogs GithubWorkflowRunLogs
-	err := r.do(ctx, nil, &workflowRunLogs, requestOptions{
+func (r *Repo) GetWorkflowRunLogs(ctx context.Context, repository string, runId int64) (io.ReadCloser, error) {
+	// Get the logs archive of a workflow run, GitHub redirects to a short-lived download URL that the client follows.
+	// The archive of a large run takes a while, the caller reads it from the returned body and closes it.
+	var archive io.ReadCloser
+	err := r.do(ctx, nil, &archive, requestOptions{
+		method: http.MethodGet,
+		path:   r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/logs",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (r *Repo) ListArtifacts(ctx context.Context, repository string, runId int64) ([]Artifact, error) {
+	// List the artifacts of a workflow run
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/artifacts",
+		contentType: "application/json",
+	}, 0, func(page Artifacts) []Artifact {
+		return page.Artifacts
+	})
+}
+
+func (r *Repo) DownloadArtifact(ctx context.Context, repository string, artifactId int64) (io.ReadCloser, error) {
+	// Download the zip archive of an artifact, GitHub redirects to a short-lived download URL that the client follows.
+	// The caller reads the archive from the returned body and closes it.
+	var archive io.ReadCloser
+	err := r.do(ctx, nil, &archive, requestOptions{
+		method: http.MethodGet,
+		path:   r.apiURL + "/repos/" + repository + "/actions/artifacts/" + strconv.FormatInt(artifactId, 10) + "/zip",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (r *Repo) DeleteArtifact(ctx context.Context, repository string, artifactId int64) error {
+	// Delete an artifact
+	err := r.do(ctx, nil, nil, requestOptions{
+		method:      http.MethodDelete,
+		path:        r.apiURL + "/repos/" + repository + "/actions/artifacts/" + strconv.FormatInt(artifactId, 10),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) ListCaches(ctx context.Context, repository string, limit int) ([]Cache, error) {
+	// List the actions caches of a repository, the largest caches first
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/caches",
+		contentType: "application/json",
+		queryParams: map[string]string{
+			"sort":      "size_in_bytes",
+			"direction": "desc",
+		},
+	}, limit, func(page Caches) []Cache {
+		return page.ActionsCache
+	})
+}
+
+func (r *Repo) GetCacheUsage(ctx context.Context, repository string) (*CacheUsage, error) {
+	// Get the total size and count of the active caches of a repository
+	var cacheUsage CacheUsage
+	err := r.do(ctx, nil, &cacheUsage, requestOptions{
 		method:      http.MethodGet,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/logs",
+		path:        r.apiURL + "/repos/" + repository + "/actions/cache/usage",
 		contentType: "application/json",
 	})
 	if err != nil {
-		return GithubWorkflowRunLogs{}, err
+		return nil, err
 	}
 
-	return workflowRunLogs, nil
+	return &cacheUsage, nil
+}
+
+func (r *Repo) DeleteCache(ctx context.Context, repository string, cacheId int64) error {
+	// Delete a cache by its id
+	err := r.do(ctx, nil, nil, requestOptions{
+		method:      http.MethodDelete,
+		path:        r.apiURL + "/repos/" + repository + "/actions/caches/" + strconv.FormatInt(cacheId, 10),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteCachesByKey(ctx context.Context, repository string, key string, ref string) ([]Cache, error) {
+	// Delete the caches with the given key, of every ref if ref is empty
+	var queryParams = map[string]string{"key": key}
+	if ref != "" {
+		queryParams["ref"] = ref
+	}
+
+	var deleted Caches
+	err := r.do(ctx, nil, &deleted, requestOptions{
+		method:      http.MethodDelete,
+		path:        r.apiURL + "/repos/" + repository + "/actions/caches",
+		contentType: "application/json",
+		queryParams: queryParams,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted.ActionsCache, nil
+}
+
+func (r *Repo) ListRunners(ctx context.Context, repository string) ([]Runner, error) {
+	// List the self-hosted runners of a repository
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runners",
+		contentType: "application/json",
+	}, 0, func(page Runners) []Runner {
+		return page.Runners
+	})
+}
+
+func (r *Repo) ListOrganizationRunners(ctx context.Context, organization string) ([]Runner, error) {
+	// List the self-hosted runners of an organization, it needs the admin:org scope
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/orgs/" + organization + "/actions/runners",
+		contentType: "application/json",
+	}, 0, func(page Runners) []Runner {
+		return page.Runners
+	})
+}
+
+func (r *Repo) ListRunnerGroups(ctx context.Context, organization string) ([]RunnerGroup, error) {
+	// List the runner groups of an organization
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/orgs/" + organization + "/actions/runner-groups",
+		contentType: "application/json",
+	}, 0, func(page RunnerGroups) []RunnerGroup {
+		return page.RunnerGroups
+	})
+}
+
+func (r *Repo) ListRunnerGroupRunners(ctx context.Context, organization string, groupId int64) ([]Runner, error) {
+	// List the self-hosted runners in a runner group of an organization
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/orgs/" + organization + "/actions/runner-groups/" + strconv.FormatInt(groupId, 10) + "/runners",
+		contentType: "application/json",
+	}, 0, func(page Runners) []Runner {
+		return page.Runners
+	})
+}
+
+func (r *Repo) ListEnvironments(ctx context.Context, repository string) ([]DeploymentEnvironment, error) {
+	// List the deployment environments of a repository
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/environments",
+		contentType: "application/json",
+	}, 0, func(page Environments) []DeploymentEnvironment {
+		return page.Environments
+	})
+}
+
+// actionsPath returns the path of the secrets or the variables of a repository, or of an environment if it is given
+func (r *Repo) actionsPath(repository string, environment string, resource string) string {
+	if environment == "" {
+		return r.apiURL + "/repos/" + repository + "/actions/" + resource
+	}
+	return r.apiURL + "/repos/" + repository + "/environments/" + url.PathEscape(environment) + "/" + resource
+}
+
+func (r *Repo) ListVariables(ctx context.Context, repository string, environment string) ([]Variable, error) {
+	// List the variables of a repository or of an environment, with their values
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.actionsPath(repository, environment, "variables"),
+		contentType: "application/json",
+	}, 0, func(page Variables) []Variable {
+		return page.Variables
+	})
+}
+
+func (r *Repo) CreateVariable(ctx context.Context, repository string, environment string, variable Variable) error {
+	// Create a variable, it fails with a conflict if the variable exists
+	err := r.do(ctx, map[string]string{"name": variable.Name, "value": variable.Value}, nil, requestOptions{
+		method:      http.MethodPost,
+		path:        r.actionsPath(repository, environment, "variables"),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) UpdateVariable(ctx context.Context, repository string, environment string, variable Variable) error {
+	// Update the value of a variable
+	err := r.do(ctx, map[string]string{"name": variable.Name, "value": variable.Value}, nil, requestOptions{
+		method:      http.MethodPatch,
+		path:        r.actionsPath(repository, environment, "variables/"+url.PathEscape(variable.Name)),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteVariable(ctx context.Context, repository string, environment string, name string) error {
+	// Delete a variable
+	err := r.do(ctx, nil, nil, requestOptions{
+		method:      http.MethodDelete,
+		path:        r.actionsPath(repository, environment, "variables/"+url.PathEscape(name)),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) ListSecrets(ctx context.Context, repository string, environment string) ([]Secret, error) {
+	// List the names of the secrets of a repository or of an environment
+	return paginate(ctx, r, requestOptions{
+		method:      http.MethodGet,
+		path:        r.actionsPath(repository, environment, "secrets"),
+		contentType: "application/json",
+	}, 0, func(page Secrets) []Secret {
+		return page.Secrets
+	})
+}
+
+func (r *Repo) GetSecretsPublicKey(ctx context.Context, repository string, environment string) (*SecretsPublicKey, error) {
+	// Get the public key to encrypt the secrets of a repository or of an environment with
+	var publicKey SecretsPublicKey
+	err := r.do(ctx, nil, &publicKey, requestOptions{
+		method:      http.MethodGet,
+		path:        r.actionsPath(repository, environment, "secrets/public-key"),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &publicKey, nil
+}
+
+func (r *Repo) PutSecret(ctx context.Context, repository string, environment string, name string, secret EncryptedSecret) error {
+	// Create or update a secret, the value must be encrypted with the public key
+	err := r.do(ctx, secret, nil, requestOptions{
+		method:      http.MethodPut,
+		path:        r.actionsPath(repository, environment, "secrets/"+url.PathEscape(name)),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) DeleteSecret(ctx context.Context, repository string, environment string, name string) error {
+	// Delete a secret
+	err := r.do(ctx, nil, nil, requestOptions{
+		method:      http.MethodDelete,
+		path:        r.actionsPath(repository, environment, "secrets/"+url.PathEscape(name)),
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetWorkflowRunTiming(ctx context.Context, repository string, runId int64) (*RunTiming, error) {
+	// Get the billable time of a workflow run, GitHub-hosted runners of private repositories are billed
+	var runTiming RunTiming
+	err := r.do(ctx, nil, &runTiming, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/timing",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &runTiming, nil
+}
+
+func (r *Repo) GetWorkflowTiming(ctx context.Context, repository string, workflowId int64) (*WorkflowTiming, error) {
+	// Get the billable time of a workflow in the current billing cycle
+	var workflowTiming WorkflowTiming
+	err := r.do(ctx, nil, &workflowTiming, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/workflows/" + strconv.FormatInt(workflowId, 10) + "/timing",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflowTiming, nil
+}
+
+func (r *Repo) GetPendingDeployments(ctx context.Context, repository string, runId int64) ([]PendingDeployment, error) {
+	// Get the environments of a workflow run that wait for a review
+	var pendingDeployments []PendingDeployment
+	err := r.do(ctx, nil, &pendingDeployments, requestOptions{
+		method:      http.MethodGet,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/pending_deployments",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingDeployments, nil
+}
+
+func (r *Repo) ReviewPendingDeployments(ctx context.Context, repository string, runId int64, review DeploymentReview) error {
+	// Approve or reject the environments of a workflow run that wait for a review
+	err := r.do(ctx, review, nil, requestOptions{
+		method:      http.MethodPost,
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/pending_deployments",
+		contentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Repo) GetJobLogs(ctx context.Context, repository string, jobId int64) (string, error) {
+	// Get the plain text logs of a job, GitHub redirects to a short-lived download URL that the client follows
+	var jobLogs []byte
+	err := r.do(ctx, nil, &jobLogs, requestOptions{
+		method: http.MethodGet,
+		path:   r.apiURL + "/repos/" + repository + "/actions/jobs/" + strconv.FormatInt(jobId, 10) + "/logs",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(jobLogs), nil
 }
 
 func (r *Repo) ReRunFailedJobs(ctx context.Context, repository string, runId int64) error {
 	// Re-run failed jobs for a given workflow run
 	err := r.do(ctx, nil, nil, requestOptions{
 		method:      http.MethodPost,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun-failed-jobs",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun-failed-jobs",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -287,7 +937,7 @@ func (r *Repo) ReRunWorkflow(ctx context.Context, repository string, runId int64
 	// Re-run a given workflow run
 	err := r.do(ctx, nil, nil, requestOptions{
 		method:      http.MethodPost,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/rerun",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -301,7 +951,7 @@ func (r *Repo) CancelWorkflow(ctx context.Context, repository string, runId int6
 	// Cancel a given workflow run
 	err := r.do(ctx, nil, nil, requestOptions{
 		method:      http.MethodPost,
-		path:        githubAPIURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/cancel",
+		path:        r.apiURL + "/repos/" + repository + "/actions/runs/" + strconv.FormatInt(runId, 10) + "/cancel",
 		contentType: "application/json",
 	})
 	if err != nil {
@@ -312,10 +962,16 @@ func (r *Repo) CancelWorkflow(ctx context.Context, repository string, runId int6
 }
 
 func (r *Repo) do(ctx context.Context, requestBody any, responseBody any, requestOptions requestOptions) error {
+	_, err := r.doWithHeader(ctx, requestBody, responseBody, requestOptions)
+	return err
+}
+
+// doWithHeader performs the request like do and returns the response headers as well
+func (r *Repo) doWithHeader(ctx context.Context, requestBody any, responseBody any, requestOptions requestOptions) (http.Header, error) {
 	// Construct the request URL
 	reqURL, err := url.Parse(requestOptions.path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Add query parameters
@@ -331,7 +987,7 @@ func (r *Repo) do(ctx context.Context, requestBody any, responseBody any, reques
 		if requestBody != nil {
 			reqBody, err = json.Marshal(requestBody)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	} else {
@@ -340,51 +996,94 @@ func (r *Repo) do(ctx context.Context, requestBody any, responseBody any, reques
 		}
 	}
 
-	// Create the HTTP request
-	req, err := http.NewRequest(requestOptions.method, reqURL.String(), bytes.NewBuffer(reqBody))
-	if err != nil {
-		return err
-	}
-
-	if requestOptions.contentType == "" {
-		req.Header.Set("Content-Type", requestOptions.contentType)
+	// A streamed response body is read by the caller after the request returns, the client of the other requests times out
+	var client = r.Client
+	if _, isStreamed := responseBody.(*io.ReadCloser); isStreamed && r.downloadClient != nil {
+		client = r.downloadClient
 	}
-	if requestOptions.accept == "" {
-		req.Header.Set("Accept", requestOptions.accept)
-	}
-	req.Header.Set("Authorization", "Bearer "+r.githubToken)
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-	req = req.WithContext(ctx)
 
-	// Perform the HTTP request using the injected client
-	resp, err := r.Client.Do(req)
+	authorization, err := r.authorization(ctx, requestOptions.asApp)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var errorResponse struct {
-		Message string `json:"message"`
-	}
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		// Decode the error response body
-		err = json.NewDecoder(resp.Body).Decode(&errorResponse)
+	// Perform the HTTP request using the injected client, backing off while it is rate limited
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, requestOptions.method, reqURL.String(), bytes.NewReader(reqBody))
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		if requestOptions.contentType == "" {
+			req.Header.Set("Content-Type", requestOptions.contentType)
+		}
+		if requestOptions.accept == "" {
+			req.Header.Set("Accept", requestOptions.accept)
+		}
+		req.Header.Set("Authorization", "Bearer "+authorization)
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+		resp, err = client.Do(req)
+		if err != nil {
+			return nil, err
 		}
 
-		return errors.New(errorResponse.Message)
+		r.updateRateLimit(resp.Header)
+
+		if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			break
+		}
+
+		errorBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		delay, isRateLimited := rateLimitDelay(resp.StatusCode, resp.Header, errorBody, attempt, time.Now())
+		if !isRateLimited || attempt >= maxRateLimitRetries || delay > maxRateLimitWait {
+			return nil, newAPIError(resp, errorBody, isRateLimited)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
 	}
+	if streamedBody, ok := responseBody.(*io.ReadCloser); ok {
+		*streamedBody = resp.Body
+		return resp.Header, nil
+	}
+	defer resp.Body.Close()
 
-	// Decode the response body
-	if responseBody != nil {
+	// Decode the response body, or read it as is for the endpoints that do not respond with JSON
+	if rawBody, ok := responseBody.(*[]byte); ok {
+		*rawBody, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+	} else if responseBody != nil {
 		err = json.NewDecoder(resp.Body).Decode(responseBody)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return resp.Header, nil
+}
+
+// authorization returns the token of the requests, the current installation token for a GitHub App.
+// asApp selects the JWT of the app instead, for the endpoints of the app itself.
+func (r *Repo) authorization(ctx context.Context, asApp bool) (string, error) {
+	if r.appToken == nil {
+		return r.githubToken, nil
+	}
+	if asApp {
+		return r.appToken.JWT()
+	}
+	return r.appToken.Token(ctx)
 }
 
 type requestOptions struct {
@@ -393,6 +1092,12 @@ type requestOptions struct {
 	contentType string
 	accept      string
 	queryParams map[string]string
+	asApp       bool // authenticate with the JWT of the GitHub App instead of its installation token
+}
+
+type installationRepositories struct {
+	TotalCount   int64              `json:"total_count"`
+	Repositories []GithubRepository `json:"repositories"`
 }
 
 type githubWorkflow struct {
@@ -401,7 +1106,41 @@ type githubWorkflow struct {
 }
 
 type workflowFile struct {
-	On map[string]interface{} `yaml:"on"`
+	On workflowEvents `yaml:"on"`
+}
+
+// workflowEvents are the events of the "on" key, which is a single event, a list of events or a map of events
+type workflowEvents []string
+
+func (e *workflowEvents) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*e = workflowEvents{value.Value}
+	case yaml.SequenceNode:
+		var events []string
+		if err := value.Decode(&events); err != nil {
+			return err
+		}
+		*e = events
+	case yaml.MappingNode:
+		var events workflowEvents
+		for i := 0; i < len(value.Content); i += 2 {
+			events = append(events, value.Content[i].Value)
+		}
+		*e = events
+	default:
+		return fmt.Errorf("unexpected \"on\" of kind %d at line %d", value.Kind, value.Line)
+	}
+	return nil
+}
+
+// parseWorkflowEvents returns the events that trigger the workflow of the given file content
+func parseWorkflowEvents(fileContent string) ([]string, error) {
+	var wfFile workflowFile
+	if err := yaml.Unmarshal([]byte(fileContent), &wfFile); err != nil {
+		return nil, err
+	}
+	return wfFile.On, nil
 }
 
 type githubFile struct {
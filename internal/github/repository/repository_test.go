@@ -4,8 +4,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	pkgconfig "github.com/termkit/gama/pkg/config"
 )
 
@@ -35,7 +41,7 @@ func TestRepo_ListRepositories(t *testing.T) {
 
 	repo := newRepo(ctx)
 
-	repositories, err := repo.ListRepositories(ctx, 10)
+	repositories, err := repo.ListRepositories(ctx, RepositoriesFilter{}, 10)
 	if err != nil {
 		t.Error(err)
 	}
@@ -84,7 +90,7 @@ func TestRepo_ListWorkflowRuns(t *testing.T) {
 
 	defaultBranch := targetRepository.DefaultBranch
 
-	workflowRuns, err := repo.ListWorkflowRuns(ctx, targetRepositoryName, defaultBranch)
+	workflowRuns, err := repo.ListWorkflowRuns(ctx, targetRepositoryName, WorkflowRunsFilter{Branch: defaultBranch}, 10)
 	if err != nil {
 		t.Error(err)
 	}
@@ -97,10 +103,296 @@ func TestRepo_GetTriggerableWorkflows(t *testing.T) {
 
 	repo := newRepo(ctx)
 
-	workflows, err := repo.GetTriggerableWorkflows(ctx, "canack/tc")
+	workflows, err := repo.GetTriggerableWorkflows(ctx, "canack/tc", "")
 	if err != nil {
 		t.Error(err)
 	}
 
 	t.Log(workflows)
 }
+
+func TestRepo_GetJobLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/jobs/42/logs":
+			// GitHub redirects to a short-lived URL of the log file
+			http.Redirect(w, r, "/blob/42.txt", http.StatusFound)
+		case "/blob/42.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("2024-03-01T10:00:00.0000000Z ##[error]failed\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	logs, err := repo.GetJobLogs(context.Background(), "owner/repo", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-01T10:00:00.0000000Z ##[error]failed\n", logs)
+}
+
+func TestRepo_GetWorkflowRunLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/runs/42/logs":
+			http.Redirect(w, r, "/blob/42.zip", http.StatusFound)
+		case "/blob/42.zip":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write([]byte("PK archive"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// The archive is downloaded without the timeout of the other requests
+	repo := &Repo{Client: &http.Client{Timeout: time.Nanosecond}, downloadClient: server.Client(), apiURL: server.URL}
+
+	archive, err := repo.GetWorkflowRunLogs(context.Background(), "owner/repo", 42)
+	assert.NoError(t, err)
+	defer archive.Close()
+
+	content, err := io.ReadAll(archive)
+	assert.NoError(t, err)
+	assert.Equal(t, "PK archive", string(content))
+}
+
+func TestRepo_DownloadArtifact(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/artifacts/7/zip":
+			http.Redirect(w, r, "/blob/7.zip", http.StatusFound)
+		case "/blob/7.zip":
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write([]byte("PK archive"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	archive, err := repo.DownloadArtifact(context.Background(), "owner/repo", 7)
+	assert.NoError(t, err)
+	defer archive.Close()
+
+	content, err := io.ReadAll(archive)
+	assert.NoError(t, err)
+	assert.Equal(t, "PK archive", string(content))
+
+	_, err = repo.DownloadArtifact(context.Background(), "owner/repo", 8)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestRepo_ReviewPendingDeployments(t *testing.T) {
+	var review DeploymentReview
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/owner/repo/actions/runs/5/pending_deployments", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&review))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	err := repo.ReviewPendingDeployments(context.Background(), "owner/repo", 5, DeploymentReview{
+		EnvironmentIDs: []int64{161088068},
+		State:          "approved",
+		Comment:        "Ship it!",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, DeploymentReview{EnvironmentIDs: []int64{161088068}, State: "approved", Comment: "Ship it!"}, review)
+}
+
+func TestWorkflowRunsFilter_QueryParams(t *testing.T) {
+	createdAfter := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	assert.Equal(t, map[string]string{}, WorkflowRunsFilter{}.queryParams())
+	assert.Equal(t, map[string]string{
+		"branch":                "main",
+		"event":                 "push",
+		"actor":                 "octocat",
+		"status":                "failure",
+		"head_sha":              "3f2a1b",
+		"exclude_pull_requests": "true",
+		"created":               "2024-03-01T00:00:00Z..2024-03-31T23:59:59Z",
+	}, WorkflowRunsFilter{
+		Workflow:            "deploy.yml",
+		Branch:              "main",
+		Event:               "push",
+		Actor:               "octocat",
+		Status:              "failure",
+		HeadSHA:             "3f2a1b",
+		CreatedAfter:        createdAfter,
+		CreatedBefore:       createdBefore,
+		ExcludePullRequests: true,
+	}.queryParams())
+	assert.Equal(t, map[string]string{"created": ">=2024-03-01T00:00:00Z"}, WorkflowRunsFilter{CreatedAfter: createdAfter}.queryParams())
+	assert.Equal(t, map[string]string{"created": "<=2024-03-31T23:59:59Z"}, WorkflowRunsFilter{CreatedBefore: createdBefore}.queryParams())
+}
+
+func TestRepo_ListWorkflowRuns_OfWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/actions/workflows/deploy.yml/runs", r.URL.Path)
+		assert.Equal(t, "workflow_dispatch", r.URL.Query().Get("event"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count": 1, "workflow_runs": [{"id": 42}]}`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	workflowRuns, err := repo.ListWorkflowRuns(context.Background(), "owner/repo", WorkflowRunsFilter{
+		Workflow: ".github/workflows/deploy.yml",
+		Event:    "workflow_dispatch",
+	}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), workflowRuns.TotalCount)
+	assert.Equal(t, int64(42), workflowRuns.WorkflowRuns[0].ID)
+}
+
+func TestParseWorkflowEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		events  []string
+	}{
+		{name: "single event", content: "on: push", events: []string{"push"}},
+		{name: "list of events", content: "on: [push, pull_request]", events: []string{"push", "pull_request"}},
+		{name: "map of events", content: "on:\n  schedule:\n    - cron: '0 3 * * *'\n  workflow_dispatch:\n", events: []string{"schedule", "workflow_dispatch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := parseWorkflowEvents(tt.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.events, events)
+		})
+	}
+}
+
+func TestRepo_DisableWorkflow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/repos/owner/repo/actions/workflows/161335/disable", r.URL.Path)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	err := repo.DisableWorkflow(context.Background(), "owner/repo", 161335)
+	assert.NoError(t, err)
+}
+
+func TestRepo_GetWorkflowRunTiming(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/actions/runs/30433642/timing", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"billable": {"UBUNTU": {"total_ms": 180000, "jobs": 1}, "MACOS": {"total_ms": 240000, "jobs": 4}}, "run_duration_ms": 500000}`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	runTiming, err := repo.GetWorkflowRunTiming(context.Background(), "owner/repo", 30433642)
+	assert.NoError(t, err)
+	assert.Equal(t, &RunTiming{
+		Billable: map[string]RunBillable{
+			"UBUNTU": {TotalMS: 180000, Jobs: 1},
+			"MACOS":  {TotalMS: 240000, Jobs: 4},
+		},
+		RunDurationMS: 500000,
+	}, runTiming)
+}
+
+func TestRepo_DeleteCachesByKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/repos/owner/repo/actions/caches", r.URL.Path)
+		assert.Equal(t, "Linux-node-958aff96", r.URL.Query().Get("key"))
+		assert.Equal(t, "refs/heads/main", r.URL.Query().Get("ref"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count": 1, "actions_caches": [{"id": 505, "key": "Linux-node-958aff96", "size_in_bytes": 1024}]}`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	deleted, err := repo.DeleteCachesByKey(context.Background(), "owner/repo", "Linux-node-958aff96", "refs/heads/main")
+	assert.NoError(t, err)
+	assert.Equal(t, []Cache{{ID: 505, Key: "Linux-node-958aff96", SizeInBytes: 1024}}, deleted)
+}
+
+func TestRepo_ListRunnerGroupRunners(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/orgs/owner/actions/runner-groups/7/runners", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count": 1, "runners": [{"id": 23, "name": "mac-1", "os": "macOS", "status": "online", "busy": true,
+			"labels": [{"id": 5, "name": "self-hosted", "type": "read-only"}, {"id": 7, "name": "gpu", "type": "custom"}]}]}`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	runners, err := repo.ListRunnerGroupRunners(context.Background(), "owner", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []Runner{{
+		ID: 23, Name: "mac-1", OS: "macOS", Status: "online", Busy: true,
+		Labels: []RunnerLabel{{ID: 5, Name: "self-hosted", Type: "read-only"}, {ID: 7, Name: "gpu", Type: "custom"}},
+	}}, runners)
+}
+
+func TestRepo_PutSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/repos/owner/repo/environments/production eu/secrets/DEPLOY_TOKEN", r.URL.Path)
+
+		var body EncryptedSecret
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, EncryptedSecret{EncryptedValue: "c2VhbGVk", KeyID: "568250167242549743"}, body)
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	err := repo.PutSecret(context.Background(), "owner/repo", "production eu", "DEPLOY_TOKEN",
+		EncryptedSecret{EncryptedValue: "c2VhbGVk", KeyID: "568250167242549743"})
+	assert.NoError(t, err)
+}
+
+func TestRepo_ListRepositories_Filter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/user/repos", r.URL.Path)
+		assert.Equal(t, "owner,organization_member", r.URL.Query().Get("affiliation"))
+		assert.Equal(t, "public", r.URL.Query().Get("visibility"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"full_name": "termkit/gama", "default_branch": "main"}]`))
+	}))
+	defer server.Close()
+
+	repo := &Repo{Client: server.Client(), apiURL: server.URL}
+
+	repositories, err := repo.ListRepositories(context.Background(), RepositoriesFilter{
+		Affiliation: "owner,organization_member",
+		Visibility:  "public",
+	}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, repositories, 1)
+	assert.Equal(t, "termkit/gama", repositories[0].FullName)
+}
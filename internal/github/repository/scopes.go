@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TokenInfo describes the token of the requests, as GitHub reports it in the headers of the /user response
+type TokenInfo struct {
+	User           GithubUser
+	Scopes         []string  // X-OAuth-Scopes, the scopes of a classic token
+	AcceptedScopes []string  // X-Accepted-OAuth-Scopes, the scopes the endpoint accepts
+	Classic        bool      // whether the token has OAuth scopes, fine-grained and installation tokens have none
+	App            bool      // whether the token is an installation token of a GitHub App
+	ExpiresAt      time.Time // github-authentication-token-expiration, zero if the token does not expire
+}
+
+// impliedScopes are the scopes that a scope grants as well
+var impliedScopes = map[string][]string{
+	"repo":            {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":       {"write:org", "read:org", "manage_runners:org"},
+	"write:org":       {"read:org"},
+	"admin:repo_hook": {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook": {"read:repo_hook"},
+	"user":            {"read:user", "user:email", "user:follow"},
+}
+
+// HasScope reports whether the scopes of a token grant the scope, directly or through a broader scope like repo
+func HasScope(scopes []string, scope string) bool {
+	for _, tokenScope := range scopes {
+		if tokenScope == scope {
+			return true
+		}
+		for _, implied := range impliedScopes[tokenScope] {
+			if implied == scope || HasScope([]string{implied}, scope) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseScopes reads a scope header, like "repo, workflow". ok is false if the response has no such header.
+func parseScopes(header http.Header, name string) (scopes []string, ok bool) {
+	values := header.Values(name)
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, true
+}
+
+// parseTokenExpiration reads the github-authentication-token-expiration header, like "2024-06-01 12:00:00 UTC"
+func parseTokenExpiration(header http.Header) time.Time {
+	value := header.Get("github-authentication-token-expiration")
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700", time.RFC3339} {
+		if expiresAt, err := time.Parse(layout, value); err == nil {
+			return expiresAt
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepo_GetTokenInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer classic":
+			w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+			w.Header().Set("X-Accepted-OAuth-Scopes", "")
+			w.Header().Set("github-authentication-token-expiration", "2024-06-01 12:00:00 UTC")
+		case "Bearer no scopes":
+			w.Header().Set("X-OAuth-Scopes", "")
+		}
+		_, _ = w.Write([]byte(`{"login":"octocat","name":"The Octocat"}`))
+	}))
+	defer server.Close()
+
+	t.Run("classic", func(t *testing.T) {
+		repo := &Repo{Client: server.Client(), apiURL: server.URL, githubToken: "classic"}
+
+		tokenInfo, err := repo.GetTokenInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "octocat", tokenInfo.User.Login)
+		assert.True(t, tokenInfo.Classic)
+		assert.Equal(t, []string{"repo", "read:org"}, tokenInfo.Scopes)
+		assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), tokenInfo.ExpiresAt.UTC())
+	})
+
+	t.Run("classic without scopes", func(t *testing.T) {
+		repo := &Repo{Client: server.Client(), apiURL: server.URL, githubToken: "no scopes"}
+
+		tokenInfo, err := repo.GetTokenInfo(context.Background())
+		require.NoError(t, err)
+		assert.True(t, tokenInfo.Classic)
+		assert.Empty(t, tokenInfo.Scopes)
+		assert.True(t, tokenInfo.ExpiresAt.IsZero())
+	})
+
+	t.Run("fine-grained", func(t *testing.T) {
+		repo := &Repo{Client: server.Client(), apiURL: server.URL, githubToken: "fine-grained"}
+
+		tokenInfo, err := repo.GetTokenInfo(context.Background())
+		require.NoError(t, err)
+		assert.False(t, tokenInfo.Classic)
+	})
+}
+
+func TestAPIError_MissingScopes(t *testing.T) {
+	newError := func(scopes string, acceptedScopes string) *APIError {
+		resp := &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+		if scopes != "-" {
+			resp.Header.Set("X-OAuth-Scopes", scopes)
+		}
+		resp.Header.Set("X-Accepted-OAuth-Scopes", acceptedScopes)
+		return newAPIError(resp, []byte(`{"message":"Not Found"}`), false)
+	}
+
+	assert.Equal(t, []string{"repo", "workflow"}, newError("read:org", "repo, workflow").MissingScopes())
+	assert.Empty(t, newError("repo", "public_repo").MissingScopes(), "repo grants public_repo")
+	assert.Empty(t, newError("-", "workflow").MissingScopes(), "fine-grained tokens have no scopes")
+	assert.Empty(t, newError("repo", "").MissingScopes())
+}
+
+func TestHasScope(t *testing.T) {
+	assert.True(t, HasScope([]string{"admin:org"}, "read:org"))
+	assert.True(t, HasScope([]string{"repo"}, "repo:status"))
+	assert.False(t, HasScope([]string{"public_repo"}, "repo"))
+	assert.False(t, HasScope(nil, "workflow"))
+}
@@ -67,7 +67,14 @@ type GithubRepository struct {
 }
 
 type GithubBranch struct {
-	Name string `json:"name"`
+	Name      string       `json:"name"`
+	Protected bool         `json:"protected"`
+	Commit    BranchCommit `json:"commit"`
+}
+
+type BranchCommit struct {
+	SHA string `json:"sha"`
+	Url string `json:"url"`
 }
 
 type Workflow struct {
@@ -110,14 +117,224 @@ type WorkflowRun struct {
 	ArtifactsURL  string `json:"artifacts_url"`
 }
 
+// RepositoriesFilter narrows the repositories of the authenticated user, empty fields use the defaults of GitHub
+type RepositoriesFilter struct {
+	Affiliation string // comma separated owner, collaborator and organization_member
+	Visibility  string // all, public or private
+}
+
+// WorkflowRunsFilter narrows the workflow runs, empty fields are not filtered
+type WorkflowRunsFilter struct {
+	Workflow            string    // id or file name of the workflow, like deploy.yml
+	Branch              string    // branch the runs are triggered on
+	Event               string    // event that triggers the runs, like push, workflow_dispatch
+	Actor               string    // login of the user that triggers the runs
+	Status              string    // status or conclusion of the runs, like in_progress, failure
+	HeadSHA             string    // commit the runs are triggered for
+	CreatedAfter        time.Time // runs created at or after the time
+	CreatedBefore       time.Time // runs created at or before the time
+	ExcludePullRequests bool      // leave the pull requests out of the runs, which makes the response faster
+}
+
+type WorkflowJobs struct {
+	TotalCount int64         `json:"total_count"`
+	Jobs       []WorkflowJob `json:"jobs"`
+}
+
+type WorkflowJob struct {
+	ID              int64          `json:"id"`
+	RunID           int64          `json:"run_id"`
+	RunAttempt      int            `json:"run_attempt"`
+	Name            string         `json:"name"`
+	WorkflowName    string         `json:"workflow_name"`
+	HeadBranch      string         `json:"head_branch"`
+	HeadSHA         string         `json:"head_sha"`
+	Status          string         `json:"status"`
+	Conclusion      string         `json:"conclusion"`
+	CreatedAt       time.Time      `json:"created_at"`
+	StartedAt       time.Time      `json:"started_at"`
+	CompletedAt     time.Time      `json:"completed_at"`
+	Labels          []string       `json:"labels"`
+	RunnerID        int64          `json:"runner_id"`
+	RunnerName      string         `json:"runner_name"`
+	RunnerGroupName string         `json:"runner_group_name"`
+	HTMLURL         string         `json:"html_url"`
+	Steps           []WorkflowStep `json:"steps"`
+}
+
+type WorkflowStep struct {
+	Number      int       `json:"number"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type Artifacts struct {
+	TotalCount int64      `json:"total_count"`
+	Artifacts  []Artifact `json:"artifacts"`
+}
+
+type Artifact struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	SizeInBytes        int64     `json:"size_in_bytes"`
+	ArchiveDownloadURL string    `json:"archive_download_url"`
+	Expired            bool      `json:"expired"`
+	CreatedAt          time.Time `json:"created_at"`
+	ExpiresAt          time.Time `json:"expires_at"`
+}
+
+type Caches struct {
+	TotalCount   int64   `json:"total_count"`
+	ActionsCache []Cache `json:"actions_caches"`
+}
+
+type Cache struct {
+	ID             int64     `json:"id"`
+	Ref            string    `json:"ref"`
+	Key            string    `json:"key"`
+	Version        string    `json:"version"`
+	SizeInBytes    int64     `json:"size_in_bytes"`
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CacheUsage struct {
+	FullName                string `json:"full_name"`
+	ActiveCachesSizeInBytes int64  `json:"active_caches_size_in_bytes"`
+	ActiveCachesCount       int64  `json:"active_caches_count"`
+}
+
+type Runners struct {
+	TotalCount int64    `json:"total_count"`
+	Runners    []Runner `json:"runners"`
+}
+
+type Runner struct {
+	ID     int64         `json:"id"`
+	Name   string        `json:"name"`
+	OS     string        `json:"os"`
+	Status string        `json:"status"` // online or offline
+	Busy   bool          `json:"busy"`
+	Labels []RunnerLabel `json:"labels"`
+}
+
+type RunnerLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // read-only or custom
+}
+
+type RunnerGroups struct {
+	TotalCount   int64         `json:"total_count"`
+	RunnerGroups []RunnerGroup `json:"runner_groups"`
+}
+
+type RunnerGroup struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"` // all, selected or private
+	Default    bool   `json:"default"`
+}
+
+type Variables struct {
+	TotalCount int64      `json:"total_count"`
+	Variables  []Variable `json:"variables"`
+}
+
+type Variable struct {
+	Name      string    `json:"name"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Secrets struct {
+	TotalCount int64    `json:"total_count"`
+	Secrets    []Secret `json:"secrets"`
+}
+
+// Secret is the name of an encrypted secret, GitHub never returns its value
+type Secret struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SecretsPublicKey is the key the values of the secrets are encrypted with before they are sent
+type SecretsPublicKey struct {
+	KeyID string `json:"key_id"`
+	Key   string `json:"key"` // base64 encoded
+}
+
+type EncryptedSecret struct {
+	EncryptedValue string `json:"encrypted_value"` // base64 encoded sealed box of the value
+	KeyID          string `json:"key_id"`
+}
+
+type Environments struct {
+	TotalCount   int64                   `json:"total_count"`
+	Environments []DeploymentEnvironment `json:"environments"`
+}
+
+type PendingDeployment struct {
+	Environment           DeploymentEnvironment `json:"environment"`
+	WaitTimer             int                   `json:"wait_timer"` // minutes to wait before the deployment starts
+	WaitTimerStartedAt    time.Time             `json:"wait_timer_started_at"`
+	CurrentUserCanApprove bool                  `json:"current_user_can_approve"`
+	Reviewers             []DeploymentReviewer  `json:"reviewers"`
+}
+
+type DeploymentEnvironment struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+type DeploymentReviewer struct {
+	Type     string `json:"type"` // User or Team
+	Reviewer struct {
+		Login string `json:"login"` // login of a user
+		Name  string `json:"name"`  // name of a team
+	} `json:"reviewer"`
+}
+
+type DeploymentReview struct {
+	EnvironmentIDs []int64 `json:"environment_ids"`
+	State          string  `json:"state"` // approved or rejected
+	Comment        string  `json:"comment"`
+}
+
+// RunTiming is the billable time of a workflow run, by the operating system of its runners like UBUNTU, MACOS, WINDOWS
+type RunTiming struct {
+	Billable      map[string]RunBillable `json:"billable"`
+	RunDurationMS int64                  `json:"run_duration_ms"`
+}
+
+type RunBillable struct {
+	TotalMS int64 `json:"total_ms"`
+	Jobs    int   `json:"jobs"`
+}
+
+// WorkflowTiming is the billable time of a workflow in the current billing cycle
+type WorkflowTiming struct {
+	Billable map[string]WorkflowBillable `json:"billable"`
+}
+
+type WorkflowBillable struct {
+	TotalMS int64 `json:"total_ms"`
+}
+
+type GithubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
 type Actor struct {
 	Id        int64  `json:"id"`
 	Login     string `json:"login"`
 	AvatarUrl string `json:"avatar_url"`
 }
-
-type GithubWorkflowRunLogs struct {
-	TotalSize int    `json:"total_size"`
-	Url       string `json:"url"`
-	Download  string `json:"download_url"`
-}
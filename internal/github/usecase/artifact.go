@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (u useCase) ListArtifacts(ctx context.Context, input ListArtifactsInput) (*ListArtifactsOutput, error) {
+	githubArtifacts, err := u.githubRepository.ListArtifacts(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []Artifact
+	for _, githubArtifact := range githubArtifacts {
+		artifacts = append(artifacts, Artifact{
+			ID:          githubArtifact.ID,
+			Name:        githubArtifact.Name,
+			SizeInBytes: githubArtifact.SizeInBytes,
+			Expired:     githubArtifact.Expired,
+			ExpiresAt:   githubArtifact.ExpiresAt,
+		})
+	}
+
+	return &ListArtifactsOutput{
+		Artifacts: artifacts,
+	}, nil
+}
+
+func (u useCase) DownloadArtifact(ctx context.Context, input DownloadArtifactInput) (*DownloadArtifactOutput, error) {
+	if err := os.MkdirAll(input.Directory, 0o755); err != nil {
+		return nil, err
+	}
+
+	archive, err := u.githubRepository.DownloadArtifact(ctx, input.Repository, input.ArtifactID)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	// Stream the archive to a temporary file, it is renamed once it is complete
+	file, err := os.CreateTemp(input.Directory, ".gama-artifact-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, archive)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	name := filepath.Base(filepath.Clean("/" + input.Name))
+	if input.Extract {
+		directory := filepath.Join(input.Directory, name)
+		if err := extractZip(file.Name(), directory); err != nil {
+			return nil, fmt.Errorf("failed to extract artifact: %w", err)
+		}
+
+		return &DownloadArtifactOutput{
+			Path: directory,
+		}, nil
+	}
+
+	archivePath := filepath.Join(input.Directory, name+".zip")
+	if err := os.Rename(file.Name(), archivePath); err != nil {
+		return nil, err
+	}
+
+	return &DownloadArtifactOutput{
+		Path: archivePath,
+	}, nil
+}
+
+func (u useCase) DeleteArtifact(ctx context.Context, input DeleteArtifactInput) (*DeleteArtifactOutput, error) {
+	err := u.githubRepository.DeleteArtifact(ctx, input.Repository, input.ArtifactID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteArtifactOutput{}, nil
+}
+
+// extractZip extracts the archive into the directory, refusing the entries that point outside of it
+func extractZip(archivePath string, directory string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, zipFile := range reader.File {
+		target := filepath.Join(directory, zipFile.Name)
+		if target != directory && !strings.HasPrefix(target, filepath.Clean(directory)+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %q is outside of the directory", zipFile.Name)
+		}
+
+		if zipFile.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := extractZipFile(zipFile, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipFile(zipFile *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	source, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, source)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
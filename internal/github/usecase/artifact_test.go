@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// artifactRepository serves a zip archive of the given files for every artifact
+type artifactRepository struct {
+	gr.Repository
+
+	files map[string]string
+}
+
+func (r *artifactRepository) DownloadArtifact(ctx context.Context, repository string, artifactId int64) (io.ReadCloser, error) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range r.files {
+		file, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return io.NopCloser(&buffer), nil
+}
+
+func TestUseCase_DownloadArtifact(t *testing.T) {
+	githubRepository := &artifactRepository{files: map[string]string{
+		"report.xml":       "<testsuites/>",
+		"coverage/out.txt": "mode: set",
+	}}
+
+	t.Run("archive", func(t *testing.T) {
+		directory := t.TempDir()
+
+		output, err := New(githubRepository).DownloadArtifact(context.Background(), DownloadArtifactInput{
+			Repository: "owner/repo", ArtifactID: 1, Name: "test-results", Directory: directory,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(directory, "test-results.zip"), output.Path)
+
+		reader, err := zip.OpenReader(output.Path)
+		assert.NoError(t, err)
+		assert.Len(t, reader.File, 2)
+		assert.NoError(t, reader.Close())
+
+		// The temporary file is renamed
+		entries, err := os.ReadDir(directory)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("extract", func(t *testing.T) {
+		directory := t.TempDir()
+
+		output, err := New(githubRepository).DownloadArtifact(context.Background(), DownloadArtifactInput{
+			Repository: "owner/repo", ArtifactID: 1, Name: "test-results", Directory: directory, Extract: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(directory, "test-results"), output.Path)
+
+		content, err := os.ReadFile(filepath.Join(output.Path, "coverage", "out.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "mode: set", string(content))
+
+		entries, err := os.ReadDir(directory)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("entry outside of the directory", func(t *testing.T) {
+		directory := t.TempDir()
+		maliciousRepository := &artifactRepository{files: map[string]string{"../../evil.sh": "rm -rf /"}}
+
+		_, err := New(maliciousRepository).DownloadArtifact(context.Background(), DownloadArtifactInput{
+			Repository: "owner/repo", ArtifactID: 1, Name: "evil", Directory: directory, Extract: true,
+		})
+		assert.Error(t, err)
+
+		_, err = os.Stat(filepath.Join(directory, "..", "evil.sh"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("name is not a path", func(t *testing.T) {
+		directory := t.TempDir()
+
+		output, err := New(githubRepository).DownloadArtifact(context.Background(), DownloadArtifactInput{
+			Repository: "owner/repo", ArtifactID: 1, Name: "../outside", Directory: directory,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(directory, "outside.zip"), output.Path)
+	})
+}
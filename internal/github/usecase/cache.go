@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// cacheLimitInBytes is the size of the caches of a repository that GitHub evicts the least recently used caches after
+const cacheLimitInBytes = 10 * 1024 * 1024 * 1024
+
+func (u useCase) ListCaches(ctx context.Context, input ListCachesInput) (*ListCachesOutput, error) {
+	githubCaches, err := u.githubRepository.ListCaches(ctx, input.Repository, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheUsage, err := u.githubRepository.GetCacheUsage(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var caches []Cache
+	for _, githubCache := range githubCaches {
+		caches = append(caches, Cache{
+			ID:             githubCache.ID,
+			Key:            githubCache.Key,
+			Ref:            githubCache.Ref,
+			SizeInBytes:    githubCache.SizeInBytes,
+			LastAccessedAt: githubCache.LastAccessedAt,
+			CreatedAt:      githubCache.CreatedAt,
+		})
+	}
+
+	return &ListCachesOutput{
+		Caches:       caches,
+		UsageInBytes: cacheUsage.ActiveCachesSizeInBytes,
+		LimitInBytes: cacheLimitInBytes,
+	}, nil
+}
+
+func (u useCase) DeleteCaches(ctx context.Context, input DeleteCachesInput) (*DeleteCachesOutput, error) {
+	if len(input.CacheIDs) == 0 {
+		if input.Key == "" {
+			return nil, errors.New("no cache to delete, a cache id or key is required")
+		}
+
+		deleted, err := u.githubRepository.DeleteCachesByKey(ctx, input.Repository, input.Key, input.Ref)
+		if err != nil {
+			return nil, err
+		}
+		return &DeleteCachesOutput{Deleted: len(deleted)}, nil
+	}
+
+	for i, cacheID := range input.CacheIDs {
+		if err := u.githubRepository.DeleteCache(ctx, input.Repository, cacheID); err != nil {
+			return nil, fmt.Errorf("deleted %d of %d caches: %w", i, len(input.CacheIDs), err)
+		}
+	}
+
+	return &DeleteCachesOutput{Deleted: len(input.CacheIDs)}, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// cacheRepository deletes every cache but the missing one
+type cacheRepository struct {
+	gr.Repository
+
+	deleted []int64
+}
+
+func (r *cacheRepository) DeleteCache(ctx context.Context, repository string, cacheId int64) error {
+	if cacheId == 404 {
+		return &gr.APIError{StatusCode: http.StatusNotFound}
+	}
+	r.deleted = append(r.deleted, cacheId)
+	return nil
+}
+
+func (r *cacheRepository) DeleteCachesByKey(ctx context.Context, repository string, key string, ref string) ([]gr.Cache, error) {
+	return []gr.Cache{{ID: 1, Key: key, Ref: "refs/heads/main"}, {ID: 2, Key: key, Ref: "refs/heads/dev"}}, nil
+}
+
+func TestUseCase_DeleteCaches(t *testing.T) {
+	t.Run("by id", func(t *testing.T) {
+		githubRepository := &cacheRepository{}
+
+		output, err := New(githubRepository).DeleteCaches(context.Background(), DeleteCachesInput{Repository: "owner/repo", CacheIDs: []int64{1, 2}})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, output.Deleted)
+		assert.Equal(t, []int64{1, 2}, githubRepository.deleted)
+	})
+
+	t.Run("by key", func(t *testing.T) {
+		output, err := New(&cacheRepository{}).DeleteCaches(context.Background(), DeleteCachesInput{Repository: "owner/repo", Key: "Linux-node"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, output.Deleted)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		githubRepository := &cacheRepository{}
+
+		_, err := New(githubRepository).DeleteCaches(context.Background(), DeleteCachesInput{Repository: "owner/repo", CacheIDs: []int64{1, 404, 3}})
+		assert.EqualError(t, err, "deleted 1 of 3 caches: "+(&gr.APIError{StatusCode: http.StatusNotFound}).Error())
+		assert.True(t, gr.IsNotFound(err))
+		assert.Equal(t, []int64{1}, githubRepository.deleted)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		_, err := New(&cacheRepository{}).DeleteCaches(context.Background(), DeleteCachesInput{Repository: "owner/repo"})
+		assert.Error(t, err)
+	})
+}
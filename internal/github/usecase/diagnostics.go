@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// requiredScopes are the scopes of classic tokens that the features of GAMA need, with what they are needed for
+var requiredScopes = []MissingScope{
+	{Scope: "repo", Reason: "private repositories, re-running and cancelling workflows"},
+	{Scope: "workflow", Reason: "triggering workflows"},
+}
+
+func (u useCase) DiagnoseToken(ctx context.Context, input DiagnoseTokenInput) (*DiagnoseTokenOutput, error) {
+	tokenInfo, err := u.githubRepository.GetTokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var output = DiagnoseTokenOutput{
+		Login:          tokenInfo.User.Login,
+		Name:           tokenInfo.User.Name,
+		Scopes:         tokenInfo.Scopes,
+		AcceptedScopes: tokenInfo.AcceptedScopes,
+		ExpiresAt:      tokenInfo.ExpiresAt,
+	}
+
+	switch {
+	case tokenInfo.App:
+		output.TokenType = TokenTypeApp
+	case tokenInfo.Classic:
+		output.TokenType = TokenTypeClassic
+	default:
+		// Fine-grained tokens have permissions instead of scopes, GitHub does not report them
+		output.TokenType = TokenTypeFineGrained
+	}
+
+	if output.TokenType == TokenTypeClassic {
+		for _, required := range requiredScopes {
+			if !gr.HasScope(tokenInfo.Scopes, required.Scope) {
+				output.MissingScopes = append(output.MissingScopes, required)
+			}
+		}
+	}
+
+	return &output, nil
+}
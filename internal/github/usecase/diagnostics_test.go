@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+type tokenInfoRepository struct {
+	gr.Repository
+
+	tokenInfo gr.TokenInfo
+}
+
+func (r *tokenInfoRepository) GetTokenInfo(ctx context.Context) (*gr.TokenInfo, error) {
+	return &r.tokenInfo, nil
+}
+
+func TestUseCase_DiagnoseToken(t *testing.T) {
+	t.Run("classic token without workflow scope", func(t *testing.T) {
+		u := New(&tokenInfoRepository{tokenInfo: gr.TokenInfo{
+			User:    gr.GithubUser{Login: "octocat"},
+			Scopes:  []string{"repo", "read:org"},
+			Classic: true,
+		}})
+
+		diagnosis, err := u.DiagnoseToken(context.Background(), DiagnoseTokenInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, "octocat", diagnosis.Login)
+		assert.Equal(t, TokenTypeClassic, diagnosis.TokenType)
+		assert.Equal(t, []MissingScope{requiredScopes[1]}, diagnosis.MissingScopes)
+	})
+
+	t.Run("classic token with public_repo only", func(t *testing.T) {
+		u := New(&tokenInfoRepository{tokenInfo: gr.TokenInfo{
+			Scopes:  []string{"public_repo", "workflow"},
+			Classic: true,
+		}})
+
+		diagnosis, err := u.DiagnoseToken(context.Background(), DiagnoseTokenInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, []MissingScope{requiredScopes[0]}, diagnosis.MissingScopes)
+	})
+
+	t.Run("fine-grained token", func(t *testing.T) {
+		u := New(&tokenInfoRepository{tokenInfo: gr.TokenInfo{User: gr.GithubUser{Login: "octocat"}}})
+
+		diagnosis, err := u.DiagnoseToken(context.Background(), DiagnoseTokenInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, TokenTypeFineGrained, diagnosis.TokenType)
+		assert.Empty(t, diagnosis.MissingScopes)
+	})
+
+	t.Run("GitHub App", func(t *testing.T) {
+		u := New(&tokenInfoRepository{tokenInfo: gr.TokenInfo{User: gr.GithubUser{Login: "ops[bot]"}, App: true}})
+
+		diagnosis, err := u.DiagnoseToken(context.Background(), DiagnoseTokenInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, TokenTypeApp, diagnosis.TokenType)
+		assert.Empty(t, diagnosis.MissingScopes)
+	})
+}
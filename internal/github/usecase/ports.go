@@ -6,11 +6,41 @@ import (
 
 type UseCase interface {
 	ListRepositories(ctx context.Context, input ListRepositoriesInput) (*ListRepositoriesOutput, error)
+	GetRepository(ctx context.Context, input GetRepositoryInput) (*GetRepositoryOutput, error)
+	ListBranches(ctx context.Context, input ListBranchesInput) (*ListBranchesOutput, error)
 	GetWorkflowHistory(ctx context.Context, input GetWorkflowHistoryInput) (*GetWorkflowHistoryOutput, error)
+	ListJobsForRun(ctx context.Context, input ListJobsForRunInput) (*ListJobsForRunOutput, error)
+	GetJob(ctx context.Context, input GetJobInput) (*GetJobOutput, error)
+	ListArtifacts(ctx context.Context, input ListArtifactsInput) (*ListArtifactsOutput, error)
+	DownloadArtifact(ctx context.Context, input DownloadArtifactInput) (*DownloadArtifactOutput, error)
+	DeleteArtifact(ctx context.Context, input DeleteArtifactInput) (*DeleteArtifactOutput, error)
+	ListCaches(ctx context.Context, input ListCachesInput) (*ListCachesOutput, error)
+	DeleteCaches(ctx context.Context, input DeleteCachesInput) (*DeleteCachesOutput, error)
+	ListRunners(ctx context.Context, input ListRunnersInput) (*ListRunnersOutput, error)
+	ListEnvironments(ctx context.Context, input ListEnvironmentsInput) (*ListEnvironmentsOutput, error)
+	ListSecrets(ctx context.Context, input ListSecretsInput) (*ListSecretsOutput, error)
+	SetSecret(ctx context.Context, input SetSecretInput) (*SetSecretOutput, error)
+	DeleteSecret(ctx context.Context, input DeleteSecretInput) (*DeleteSecretOutput, error)
+	SetVariable(ctx context.Context, input SetVariableInput) (*SetVariableOutput, error)
+	DeleteVariable(ctx context.Context, input DeleteVariableInput) (*DeleteVariableOutput, error)
+	GetPendingDeployments(ctx context.Context, input GetPendingDeploymentsInput) (*GetPendingDeploymentsOutput, error)
+	ReviewPendingDeployments(ctx context.Context, input ReviewPendingDeploymentsInput) (*ReviewPendingDeploymentsOutput, error)
+	WatchRun(ctx context.Context, input WatchRunInput) (*WatchRunOutput, error)
+	GetUsage(ctx context.Context, input GetUsageInput) (*GetUsageOutput, error)
+	GetJobLogs(ctx context.Context, input GetJobLogsInput) (*GetJobLogsOutput, error)
+	GetWorkflowRunLogs(ctx context.Context, input GetWorkflowRunLogsInput) (*GetWorkflowRunLogsOutput, error)
+	ListWorkflows(ctx context.Context, input ListWorkflowsInput) (*ListWorkflowsOutput, error)
+	EnableWorkflow(ctx context.Context, input EnableWorkflowInput) (*EnableWorkflowOutput, error)
+	DisableWorkflow(ctx context.Context, input DisableWorkflowInput) (*DisableWorkflowOutput, error)
 	GetTriggerableWorkflows(ctx context.Context, input GetTriggerableWorkflowsInput) (*GetTriggerableWorkflowsOutput, error)
 	InspectWorkflow(ctx context.Context, input InspectWorkflowInput) (*InspectWorkflowOutput, error)
 	TriggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*TriggerWorkflowOutput, error)
+	DispatchWorkflow(ctx context.Context, input DispatchWorkflowInput) (*DispatchWorkflowOutput, error)
+	FindDispatchedRun(ctx context.Context, input FindDispatchedRunInput) (*FindDispatchedRunOutput, error)
+	GetWorkflowRun(ctx context.Context, input GetWorkflowRunInput) (*GetWorkflowRunOutput, error)
 	ReRunFailedJobs(ctx context.Context, input ReRunFailedJobsInput) (*ReRunFailedJobsOutput, error)
 	ReRunWorkflow(ctx context.Context, input ReRunWorkflowInput) (*ReRunWorkflowOutput, error)
 	CancelWorkflow(ctx context.Context, input CancelWorkflowInput) (*CancelWorkflowOutput, error)
+	GetRateLimit(ctx context.Context, input GetRateLimitInput) (*GetRateLimitOutput, error)
+	DiagnoseToken(ctx context.Context, input DiagnoseTokenInput) (*DiagnoseTokenOutput, error)
 }
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+const (
+	runnerScopeRepository   = "repository"
+	runnerScopeOrganization = "organization"
+)
+
+func (u useCase) ListRunners(ctx context.Context, input ListRunnersInput) (*ListRunnersOutput, error) {
+	repositoryRunners, err := u.githubRepository.ListRunners(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var output ListRunnersOutput
+	for _, runner := range repositoryRunners {
+		output.Runners = append(output.Runners, toRunner(runner, runnerScopeRepository, ""))
+	}
+
+	organizationRunners, err := u.listOrganizationRunners(ctx, input.Repository)
+	if isInaccessible(err) {
+		output.OrganizationSkipped = true
+	} else if err != nil {
+		return nil, err
+	}
+	output.Runners = append(output.Runners, organizationRunners...)
+
+	if len(input.Labels) > 0 {
+		var runners []Runner
+		for _, runner := range output.Runners {
+			if runner.HasLabels(input.Labels) {
+				runners = append(runners, runner)
+			}
+		}
+		output.Runners = runners
+	}
+
+	return &output, nil
+}
+
+// listOrganizationRunners lists the runners of the owner of the repository with their groups
+func (u useCase) listOrganizationRunners(ctx context.Context, repository string) ([]Runner, error) {
+	organization, _, _ := strings.Cut(repository, "/")
+
+	organizationRunners, err := u.githubRepository.ListOrganizationRunners(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	// The groups are optional, the runners are listed without them if the groups cannot be read
+	var groups = make(map[int64]string)
+	runnerGroups, err := u.githubRepository.ListRunnerGroups(ctx, organization)
+	if err != nil && !isInaccessible(err) {
+		return nil, err
+	}
+	for _, runnerGroup := range runnerGroups {
+		groupRunners, err := u.githubRepository.ListRunnerGroupRunners(ctx, organization, runnerGroup.ID)
+		if err != nil && !isInaccessible(err) {
+			return nil, err
+		}
+		for _, runner := range groupRunners {
+			groups[runner.ID] = runnerGroup.Name
+		}
+	}
+
+	var runners []Runner
+	for _, runner := range organizationRunners {
+		runners = append(runners, toRunner(runner, runnerScopeOrganization, groups[runner.ID]))
+	}
+	return runners, nil
+}
+
+// isInaccessible reports whether the request failed for the owner is not an organization or the token lacks the scope
+func isInaccessible(err error) bool {
+	return gr.IsNotFound(err) || gr.IsForbidden(err)
+}
+
+func toRunner(runner gr.Runner, scope string, group string) Runner {
+	var labels []string
+	for _, label := range runner.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return Runner{
+		ID:     runner.ID,
+		Name:   runner.Name,
+		OS:     runner.OS,
+		Status: runner.Status,
+		Busy:   runner.Busy,
+		Labels: labels,
+		Scope:  scope,
+		Group:  group,
+	}
+}
+
+// HasLabels reports whether the runner has every label, labels are case-insensitive like in runs-on
+func (r Runner) HasLabels(labels []string) bool {
+	for _, label := range labels {
+		var found bool
+		for _, runnerLabel := range r.Labels {
+			if strings.EqualFold(runnerLabel, label) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// runnerRepository has a repository runner and an organization runner in a group, the organization may not be readable
+type runnerRepository struct {
+	gr.Repository
+
+	organizationErr error
+}
+
+func (r *runnerRepository) ListRunners(ctx context.Context, repository string) ([]gr.Runner, error) {
+	return []gr.Runner{
+		{ID: 1, Name: "build-1", OS: "Linux", Status: "online", Labels: []gr.RunnerLabel{{Name: "self-hosted"}, {Name: "Linux"}, {Name: "gpu"}}},
+	}, nil
+}
+
+func (r *runnerRepository) ListOrganizationRunners(ctx context.Context, organization string) ([]gr.Runner, error) {
+	if r.organizationErr != nil {
+		return nil, r.organizationErr
+	}
+	return []gr.Runner{
+		{ID: 2, Name: "shared-1", OS: "Linux", Status: "online", Busy: true, Labels: []gr.RunnerLabel{{Name: "self-hosted"}, {Name: "Linux"}}},
+	}, nil
+}
+
+func (r *runnerRepository) ListRunnerGroups(ctx context.Context, organization string) ([]gr.RunnerGroup, error) {
+	return []gr.RunnerGroup{{ID: 7, Name: "Default"}}, nil
+}
+
+func (r *runnerRepository) ListRunnerGroupRunners(ctx context.Context, organization string, groupId int64) ([]gr.Runner, error) {
+	return []gr.Runner{{ID: 2}}, nil
+}
+
+func TestUseCase_ListRunners(t *testing.T) {
+	t.Run("repository and organization", func(t *testing.T) {
+		output, err := New(&runnerRepository{}).ListRunners(context.Background(), ListRunnersInput{Repository: "owner/repo"})
+		assert.NoError(t, err)
+		assert.False(t, output.OrganizationSkipped)
+		assert.Equal(t, []Runner{
+			{ID: 1, Name: "build-1", OS: "Linux", Status: "online", Labels: []string{"self-hosted", "Linux", "gpu"}, Scope: "repository"},
+			{ID: 2, Name: "shared-1", OS: "Linux", Status: "online", Busy: true, Labels: []string{"self-hosted", "Linux"}, Scope: "organization", Group: "Default"},
+		}, output.Runners)
+	})
+
+	t.Run("filtered by labels", func(t *testing.T) {
+		output, err := New(&runnerRepository{}).ListRunners(context.Background(), ListRunnersInput{Repository: "owner/repo", Labels: []string{"linux", "GPU"}})
+		assert.NoError(t, err)
+		assert.Len(t, output.Runners, 1)
+		assert.Equal(t, "build-1", output.Runners[0].Name)
+	})
+
+	t.Run("organization is not readable", func(t *testing.T) {
+		githubRepository := &runnerRepository{organizationErr: &gr.APIError{StatusCode: http.StatusForbidden}}
+
+		output, err := New(githubRepository).ListRunners(context.Background(), ListRunnersInput{Repository: "owner/repo"})
+		assert.NoError(t, err)
+		assert.True(t, output.OrganizationSkipped)
+		assert.Len(t, output.Runners, 1)
+	})
+}
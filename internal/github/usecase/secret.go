@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func (u useCase) ListEnvironments(ctx context.Context, input ListEnvironmentsInput) (*ListEnvironmentsOutput, error) {
+	environments, err := u.githubRepository.ListEnvironments(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var output ListEnvironmentsOutput
+	for _, environment := range environments {
+		output.Environments = append(output.Environments, environment.Name)
+	}
+	return &output, nil
+}
+
+func (u useCase) ListSecrets(ctx context.Context, input ListSecretsInput) (*ListSecretsOutput, error) {
+	secrets, err := u.githubRepository.ListSecrets(ctx, input.Repository, input.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, err := u.githubRepository.ListVariables(ctx, input.Repository, input.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	var output ListSecretsOutput
+	for _, secret := range secrets {
+		output.Secrets = append(output.Secrets, Secret{
+			Name:      secret.Name,
+			UpdatedAt: secret.UpdatedAt,
+		})
+	}
+	for _, variable := range variables {
+		output.Variables = append(output.Variables, Variable{
+			Name:      variable.Name,
+			Value:     variable.Value,
+			UpdatedAt: variable.UpdatedAt,
+		})
+	}
+	return &output, nil
+}
+
+func (u useCase) SetSecret(ctx context.Context, input SetSecretInput) (*SetSecretOutput, error) {
+	publicKey, err := u.githubRepository.GetSecretsPublicKey(ctx, input.Repository, input.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedValue, err := sealSecret(publicKey.Key, input.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.githubRepository.PutSecret(ctx, input.Repository, input.Environment, input.Name, gr.EncryptedSecret{
+		EncryptedValue: encryptedValue,
+		KeyID:          publicKey.KeyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SetSecretOutput{}, nil
+}
+
+// sealSecret encrypts the value with a libsodium sealed box for the base64 encoded public key, as GitHub expects
+func sealSecret(publicKey string, value string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(key) != 32 {
+		return "", fmt.Errorf("invalid public key: %d bytes, expected 32", len(key))
+	}
+
+	var recipient [32]byte
+	copy(recipient[:], key)
+
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (u useCase) DeleteSecret(ctx context.Context, input DeleteSecretInput) (*DeleteSecretOutput, error) {
+	if err := u.githubRepository.DeleteSecret(ctx, input.Repository, input.Environment, input.Name); err != nil {
+		return nil, err
+	}
+	return &DeleteSecretOutput{}, nil
+}
+
+func (u useCase) SetVariable(ctx context.Context, input SetVariableInput) (*SetVariableOutput, error) {
+	var variable = gr.Variable{Name: input.Name, Value: input.Value}
+
+	// Variables have no create-or-update endpoint, a variable is created if it cannot be updated
+	err := u.githubRepository.UpdateVariable(ctx, input.Repository, input.Environment, variable)
+	if err == nil {
+		return &SetVariableOutput{}, nil
+	} else if !gr.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err := u.githubRepository.CreateVariable(ctx, input.Repository, input.Environment, variable); err != nil {
+		return nil, err
+	}
+	return &SetVariableOutput{Created: true}, nil
+}
+
+func (u useCase) DeleteVariable(ctx context.Context, input DeleteVariableInput) (*DeleteVariableOutput, error) {
+	if err := u.githubRepository.DeleteVariable(ctx, input.Repository, input.Environment, input.Name); err != nil {
+		return nil, err
+	}
+	return &DeleteVariableOutput{}, nil
+}
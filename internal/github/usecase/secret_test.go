@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// secretRepository keeps the secrets and the variables it receives, the variables it knows can be updated
+type secretRepository struct {
+	gr.Repository
+
+	publicKey *[32]byte
+	secrets   map[string]gr.EncryptedSecret
+	variables map[string]string
+}
+
+func (r *secretRepository) GetSecretsPublicKey(ctx context.Context, repository string, environment string) (*gr.SecretsPublicKey, error) {
+	return &gr.SecretsPublicKey{KeyID: "key-1", Key: base64.StdEncoding.EncodeToString(r.publicKey[:])}, nil
+}
+
+func (r *secretRepository) PutSecret(ctx context.Context, repository string, environment string, name string, secret gr.EncryptedSecret) error {
+	r.secrets[environment+"/"+name] = secret
+	return nil
+}
+
+func (r *secretRepository) UpdateVariable(ctx context.Context, repository string, environment string, variable gr.Variable) error {
+	if _, ok := r.variables[variable.Name]; !ok {
+		return &gr.APIError{StatusCode: http.StatusNotFound}
+	}
+	r.variables[variable.Name] = variable.Value
+	return nil
+}
+
+func (r *secretRepository) CreateVariable(ctx context.Context, repository string, environment string, variable gr.Variable) error {
+	r.variables[variable.Name] = variable.Value
+	return nil
+}
+
+func TestUseCase_SetSecret(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	githubRepository := &secretRepository{publicKey: publicKey, secrets: make(map[string]gr.EncryptedSecret)}
+
+	_, err = New(githubRepository).SetSecret(context.Background(), SetSecretInput{
+		Repository:  "owner/repo",
+		Environment: "production",
+		Name:        "DEPLOY_TOKEN",
+		Value:       "s3cr3t",
+	})
+	assert.NoError(t, err)
+
+	secret := githubRepository.secrets["production/DEPLOY_TOKEN"]
+	assert.Equal(t, "key-1", secret.KeyID)
+
+	sealed, err := base64.StdEncoding.DecodeString(secret.EncryptedValue)
+	assert.NoError(t, err)
+	value, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	assert.True(t, ok)
+	assert.Equal(t, "s3cr3t", string(value))
+}
+
+func TestSealSecret_InvalidKey(t *testing.T) {
+	_, err := sealSecret(base64.StdEncoding.EncodeToString([]byte("short")), "value")
+	assert.Error(t, err)
+}
+
+func TestUseCase_SetVariable(t *testing.T) {
+	githubRepository := &secretRepository{variables: map[string]string{"REGION": "eu-west-1"}}
+	useCase := New(githubRepository)
+
+	output, err := useCase.SetVariable(context.Background(), SetVariableInput{Repository: "owner/repo", Name: "REGION", Value: "us-east-1"})
+	assert.NoError(t, err)
+	assert.False(t, output.Created)
+
+	output, err = useCase.SetVariable(context.Background(), SetVariableInput{Repository: "owner/repo", Name: "STAGE", Value: "prod"})
+	assert.NoError(t, err)
+	assert.True(t, output.Created)
+
+	assert.Equal(t, map[string]string{"REGION": "us-east-1", "STAGE": "prod"}, githubRepository.variables)
+}
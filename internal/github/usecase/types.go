@@ -1,11 +1,24 @@
 package usecase
 
 import (
+	"time"
+
 	pw "github.com/termkit/gama/pkg/workflow"
+	pwl "github.com/termkit/gama/pkg/workflowlog"
 )
 
 type ListRepositoriesInput struct {
-	Limit int
+	Limit   int // maximum number of repositories, 0 lists all of them
+	Sources RepositorySources
+}
+
+// RepositorySources are where the repositories are listed from, the repositories found in several sources are listed once
+type RepositorySources struct {
+	Affiliation   string   // affiliation of the user's repositories, like owner,collaborator,organization_member
+	Visibility    string   // visibility of the user's repositories, all, public or private
+	SkipUser      bool     // the user's repositories are not listed, only the organizations and the included ones
+	Organizations []string // organizations whose repositories are listed
+	Include       []string // repositories listed explicitly, like owner/repo
 }
 
 type ListRepositoriesOutput struct {
@@ -17,6 +30,7 @@ type GithubRepository struct {
 	Private       bool
 	DefaultBranch string
 	Stars         int
+	HTMLURL       string
 
 	Workflows []Workflow
 	// We can add more fields here
@@ -24,9 +38,50 @@ type GithubRepository struct {
 
 // ------------------------------------------------------------
 
+type GetRepositoryInput struct {
+	Repository string
+}
+
+// GetRepositoryOutput is the repository without its workflows
+type GetRepositoryOutput struct {
+	Repository GithubRepository
+}
+
+// ------------------------------------------------------------
+
+type ListBranchesInput struct {
+	Repository string
+}
+
+type ListBranchesOutput struct {
+	Branches []GithubBranch
+}
+
+type GithubBranch struct {
+	Name      string // branch name
+	Protected bool   // whether the branch is protected
+	CommitSHA string // head commit sha
+}
+
+// ------------------------------------------------------------
+
 type GetWorkflowHistoryInput struct {
 	Repository string
 	Branch     string
+	Limit      int // maximum number of workflow runs, 0 lists all of them
+	Filter     HistoryFilter
+}
+
+// HistoryFilter narrows the workflow history, empty fields are not filtered
+type HistoryFilter struct {
+	Workflow            string    // id or file name of the workflow, like deploy.yml
+	Status              string    // status or conclusion, like in_progress, failure
+	Event               string    // triggering event, like push, workflow_dispatch
+	Actor               string    // login of the triggering user
+	CreatedAfter        time.Time // runs created at or after the time
+	CreatedBefore       time.Time // runs created at or before the time
+	HeadSHA             string    // commit of the runs
+	ExcludePullRequests bool      // leave the pull requests out of the runs
 }
 
 type GetWorkflowHistoryOutput struct {
@@ -42,6 +97,334 @@ type Workflow struct {
 	Status       string // workflow's status, like success, failure, etc.
 	Conclusion   string // workflow's conclusion, like success, failure, etc.
 	Duration     string // workflow's duration
+	HTMLURL      string // workflow run's page
+}
+
+// ------------------------------------------------------------
+
+type ListJobsForRunInput struct {
+	Repository string
+	WorkflowID int64 // workflow run id
+}
+
+type ListJobsForRunOutput struct {
+	Jobs []Job
+}
+
+type GetJobInput struct {
+	Repository string
+	JobID      int64
+}
+
+type GetJobOutput struct {
+	Job Job
+}
+
+type Job struct {
+	ID         int64    // job id
+	Name       string   // job name
+	Status     string   // job's status, like queued, in_progress, completed
+	Conclusion string   // job's conclusion, like success, failure, etc.
+	RunnerName string   // runner that runs the job
+	Labels     []string // runner labels requested by the job
+	StartedAt  string   // job's started at
+	Duration   string   // job's duration
+	HTMLURL    string   // job's page
+	Steps      []Step
+}
+
+type Step struct {
+	Number     int    // step number
+	Name       string // step name
+	Status     string // step's status, like queued, in_progress, completed
+	Conclusion string // step's conclusion, like success, failure, skipped, etc.
+	Duration   string // step's duration
+}
+
+// ------------------------------------------------------------
+
+type ListArtifactsInput struct {
+	Repository string
+	WorkflowID int64 // workflow run id
+}
+
+type ListArtifactsOutput struct {
+	Artifacts []Artifact
+}
+
+type Artifact struct {
+	ID          int64     // artifact id
+	Name        string    // artifact name
+	SizeInBytes int64     // size of the zip archive
+	Expired     bool      // expired artifacts cannot be downloaded
+	ExpiresAt   time.Time // time the artifact is deleted at
+}
+
+type DownloadArtifactInput struct {
+	Repository string
+	ArtifactID int64
+	Name       string // artifact name, used for the name of the archive or the directory
+	Directory  string // directory the artifact is downloaded to
+	Extract    bool   // extract the archive instead of keeping it
+}
+
+type DownloadArtifactOutput struct {
+	Path string // path of the archive, or of the directory the archive is extracted to
+}
+
+type DeleteArtifactInput struct {
+	Repository string
+	ArtifactID int64
+}
+
+type DeleteArtifactOutput struct{}
+
+// ------------------------------------------------------------
+
+type ListCachesInput struct {
+	Repository string
+}
+
+type ListCachesOutput struct {
+	Caches       []Cache
+	UsageInBytes int64 // total size of the active caches
+	LimitInBytes int64 // size the caches are evicted after
+}
+
+type Cache struct {
+	ID             int64
+	Key            string
+	Ref            string // ref the cache is created for, like refs/heads/main
+	SizeInBytes    int64
+	LastAccessedAt time.Time
+	CreatedAt      time.Time
+}
+
+type DeleteCachesInput struct {
+	Repository string
+	CacheIDs   []int64 // caches to delete, or
+	Key        string  // key of the caches to delete if no id is given
+	Ref        string  // ref of the caches to delete by key, every ref if it is empty
+}
+
+type DeleteCachesOutput struct {
+	Deleted int // number of deleted caches
+}
+
+// ------------------------------------------------------------
+
+type ListRunnersInput struct {
+	Repository string
+	Labels     []string // lists only the runners that have all the labels, case-insensitive
+}
+
+type ListRunnersOutput struct {
+	Runners             []Runner
+	OrganizationSkipped bool // organization runners are not listed, the owner is a user or the token cannot read them
+}
+
+type Runner struct {
+	ID     int64
+	Name   string
+	OS     string
+	Status string // online or offline
+	Busy   bool   // whether the runner runs a job
+	Labels []string
+	Scope  string // repository or organization
+	Group  string // runner group of an organization runner
+}
+
+// ------------------------------------------------------------
+
+type ListEnvironmentsInput struct {
+	Repository string
+}
+
+type ListEnvironmentsOutput struct {
+	Environments []string
+}
+
+type ListSecretsInput struct {
+	Repository  string
+	Environment string // secrets and variables of the environment, of the repository if it is empty
+}
+
+type ListSecretsOutput struct {
+	Secrets   []Secret
+	Variables []Variable
+}
+
+type Secret struct {
+	Name      string
+	UpdatedAt time.Time
+}
+
+type Variable struct {
+	Name      string
+	Value     string
+	UpdatedAt time.Time
+}
+
+type SetSecretInput struct {
+	Repository  string
+	Environment string
+	Name        string
+	Value       string // plain value, it is encrypted before it is sent
+}
+
+type SetSecretOutput struct{}
+
+type DeleteSecretInput struct {
+	Repository  string
+	Environment string
+	Name        string
+}
+
+type DeleteSecretOutput struct{}
+
+type SetVariableInput struct {
+	Repository  string
+	Environment string
+	Name        string
+	Value       string
+}
+
+type SetVariableOutput struct {
+	Created bool // whether the variable did not exist
+}
+
+type DeleteVariableInput struct {
+	Repository  string
+	Environment string
+	Name        string
+}
+
+type DeleteVariableOutput struct{}
+
+// ------------------------------------------------------------
+
+type GetPendingDeploymentsInput struct {
+	Repository string
+	WorkflowID int64 // workflow run id
+}
+
+type GetPendingDeploymentsOutput struct {
+	Deployments []PendingDeployment
+}
+
+type PendingDeployment struct {
+	EnvironmentID   int64    // environment id
+	EnvironmentName string   // environment name, like production
+	CanApprove      bool     // whether the current user is one of the reviewers
+	Reviewers       []string // logins of the users and names of the teams that can review
+	WaitTimer       int      // minutes to wait after the approval
+}
+
+type ReviewPendingDeploymentsInput struct {
+	Repository     string
+	WorkflowID     int64   // workflow run id
+	EnvironmentIDs []int64 // environments to review
+	Approve        bool    // approve the deployments, or reject them
+	Comment        string  // comment of the review
+}
+
+type ReviewPendingDeploymentsOutput struct{}
+
+// ------------------------------------------------------------
+
+type WatchRunInput struct {
+	Repository      string
+	WorkflowID      int64 // workflow run id
+	PreviousAttempt int   // attempt of the run before it is re-run, its completed state is skipped until the re-run starts
+}
+
+type WatchRunOutput struct {
+	Updates <-chan RunUpdate // closed when the run completes or the context is done
+}
+
+type RunUpdate struct {
+	Status      string       // run's status, like queued, in_progress, completed
+	Conclusion  string       // run's conclusion, set once the run is completed
+	Attempt     int          // run's attempt, it goes up when the run is re-run
+	Jobs        []Job        // jobs of the run
+	Transitions []Transition // state changes since the previous update
+	Err         error        // error of a failed poll, the watch goes on unless the run is not found or a re-run does not start
+}
+
+type Transition struct {
+	JobName string // job name, empty for the run itself
+	From    string // previous status, or conclusion if completed
+	To      string // current status, or conclusion if completed
+}
+
+// ------------------------------------------------------------
+
+type GetUsageInput struct {
+	Repositories  []string
+	CreatedAfter  time.Time // runs created at or after the time
+	CreatedBefore time.Time // runs created at or before the time, now if it is zero
+	Limit         int       // maximum number of runs per repository, 0 uses the default limit
+}
+
+// GetUsageOutput is the billable time of the completed runs in the time window, sorted by the most billed first.
+// Only the latest runs up to the limit are counted, Truncated reports that the usage is understated.
+type GetUsageOutput struct {
+	Repositories     []RepositoryUsage `json:"repositories"`
+	Workflows        []WorkflowUsage   `json:"workflows"`
+	OperatingSystems []OSUsage         `json:"operating_systems"`
+	TotalRuns        int64             `json:"total_runs"` // completed runs in the time window, counted or not
+	Truncated        bool              `json:"truncated"`  // some of the runs are not counted because of the limit
+}
+
+type RepositoryUsage struct {
+	Repository    string `json:"repository"`
+	Runs          int    `json:"runs"`            // counted runs
+	TotalRuns     int64  `json:"total_runs"`      // completed runs in the time window, counted or not
+	Truncated     bool   `json:"truncated"`       // some of the runs are not counted because of the limit
+	BillableMS    int64  `json:"billable_ms"`     // billable time of the runs
+	RunDurationMS int64  `json:"run_duration_ms"` // wall-clock time of the runs
+}
+
+type WorkflowUsage struct {
+	Repository     string           `json:"repository"`
+	WorkflowID     int64            `json:"workflow_id"`
+	Workflow       string           `json:"workflow"` // workflow name
+	Runs           int              `json:"runs"`
+	BillableMS     int64            `json:"billable_ms"`       // billable time of the runs
+	BillableMSByOS map[string]int64 `json:"billable_ms_by_os"` // billable time of the runs by the operating system, like UBUNTU
+	RunDurationMS  int64            `json:"run_duration_ms"`   // wall-clock time of the runs
+	BillingCycleMS int64            `json:"billing_cycle_ms"`  // billable time of the workflow in the current billing cycle
+}
+
+type OSUsage struct {
+	OS         string `json:"os"` // operating system of the runners, like UBUNTU, MACOS, WINDOWS
+	Jobs       int    `json:"jobs"`
+	BillableMS int64  `json:"billable_ms"`
+}
+
+// ------------------------------------------------------------
+
+type GetJobLogsInput struct {
+	Repository string
+	JobID      int64
+}
+
+type GetJobLogsOutput struct {
+	Log JobLog
+}
+
+type GetWorkflowRunLogsInput struct {
+	Repository string
+	WorkflowID int64 // run id
+}
+
+type GetWorkflowRunLogsOutput struct {
+	Logs []JobLog // logs of every job of the run
+}
+
+type JobLog struct {
+	JobName string     // job name
+	Lines   []pwl.Line // parsed log lines
 }
 
 // ------------------------------------------------------------
@@ -63,15 +446,76 @@ type TriggerWorkflowInput struct {
 	Repository   string
 	Branch       string
 	Content      string // workflow content in json format
+	NoWait       bool   // return right after the dispatch, without waiting for the run to be created
 }
 
 type TriggerWorkflowOutput struct {
-	// Return workflow information
-	// Like status url etc.
+	RunID   int64  // id of the run created by the trigger, 0 if the run is not found in time or NoWait is set
+	HTMLURL string // run's page
+	Status  string // run's initial status, like queued
+}
+
+type DispatchWorkflowInput struct {
+	WorkflowFile string
+	Repository   string
+	Branch       string
+	Content      string // workflow content in json format
+}
+
+type DispatchWorkflowOutput struct {
+	DispatchedAt  time.Time // time of the dispatch on GitHub
+	CorrelationID string    // id filled in the correlation_id input, empty if the workflow has none
+}
+
+type FindDispatchedRunInput struct {
+	WorkflowFile  string
+	Repository    string
+	Branch        string
+	DispatchedAt  time.Time
+	CorrelationID string
+}
+
+type FindDispatchedRunOutput struct {
+	RunID   int64  // id of the run created by the dispatch, 0 if the run is not found in time
+	HTMLURL string // run's page
+	Status  string // run's initial status, like queued
 }
 
 // ------------------------------------------------------------
 
+type ListWorkflowsInput struct {
+	Repository string
+	Branch     string // branch the workflow files are read from, the default branch if it is empty
+}
+
+type ListWorkflowsOutput struct {
+	Workflows []RepositoryWorkflow
+}
+
+type RepositoryWorkflow struct {
+	ID     int64
+	Name   string
+	Path   string
+	State  string   // workflow's state, like active, disabled_manually, disabled_inactivity
+	Events []string // events that trigger the workflow, empty if the file is not on the branch
+}
+
+type EnableWorkflowInput struct {
+	Repository string
+	WorkflowID int64 // workflow id, not a run id
+}
+
+type EnableWorkflowOutput struct{}
+
+type DisableWorkflowInput struct {
+	Repository string
+	WorkflowID int64 // workflow id, not a run id
+}
+
+type DisableWorkflowOutput struct{}
+
+// ------------------------------------------------------------
+
 type GetTriggerableWorkflowsInput struct {
 	Repository string
 	Branch     string
@@ -99,6 +543,20 @@ type ReRunFailedJobsOutput struct {
 
 // ------------------------------------------------------------
 
+type GetWorkflowRunInput struct {
+	Repository string
+	WorkflowID int64 // workflow run id
+}
+
+type GetWorkflowRunOutput struct {
+	Status     string // run's status, like queued, in_progress, completed
+	Conclusion string // run's conclusion, set once the run is completed
+	Attempt    int    // run's attempt, it goes up when the run is re-run
+	HTMLURL    string // run's page
+}
+
+// ------------------------------------------------------------
+
 type ReRunWorkflowInput struct {
 	Repository string
 	WorkflowID int64
@@ -116,3 +574,42 @@ type CancelWorkflowInput struct {
 
 type CancelWorkflowOutput struct {
 }
+
+// ------------------------------------------------------------
+
+type GetRateLimitInput struct {
+}
+
+type GetRateLimitOutput struct {
+	Limit     int       // maximum number of requests per hour
+	Remaining int       // number of requests remaining in the current window
+	Reset     time.Time // time at which the current window resets
+}
+
+// ------------------------------------------------------------
+
+// Types of the tokens that DiagnoseToken tells apart
+const (
+	TokenTypeClassic     = "classic"
+	TokenTypeFineGrained = "fine-grained"
+	TokenTypeApp         = "GitHub App"
+)
+
+type DiagnoseTokenInput struct {
+}
+
+type DiagnoseTokenOutput struct {
+	Login          string         // user of the token, the bot user for a GitHub App
+	Name           string         // name of the user
+	TokenType      string         // TokenTypeClassic, TokenTypeFineGrained or TokenTypeApp
+	Scopes         []string       // scopes of a classic token
+	AcceptedScopes []string       // scopes accepted by /user
+	ExpiresAt      time.Time      // zero if the token does not expire
+	MissingScopes  []MissingScope // scopes of a classic token that some features need
+}
+
+// MissingScope is a scope the token does not have, and what it is needed for
+type MissingScope struct {
+	Scope  string
+	Reason string
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+const (
+	// usageRunLimit is the default maximum number of runs per repository, every run costs a request
+	usageRunLimit = 200
+
+	// usageConcurrency is the number of timing requests made at once
+	usageConcurrency = 4
+)
+
+func (u useCase) GetUsage(ctx context.Context, input GetUsageInput) (*GetUsageOutput, error) {
+	var limit = input.Limit
+	if limit <= 0 {
+		limit = usageRunLimit
+	}
+
+	var createdBefore = input.CreatedBefore
+	if createdBefore.IsZero() {
+		createdBefore = time.Now()
+	}
+
+	var output GetUsageOutput
+	var operatingSystems = make(map[string]*OSUsage)
+	for _, repository := range input.Repositories {
+		workflowRuns, err := u.githubRepository.ListWorkflowRuns(ctx, repository, gr.WorkflowRunsFilter{
+			Status:        "completed",
+			CreatedAfter:  input.CreatedAfter,
+			CreatedBefore: createdBefore,
+		}, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		runTimings, err := u.getRunTimings(ctx, repository, workflowRuns.WorkflowRuns)
+		if err != nil {
+			return nil, err
+		}
+
+		var repositoryUsage = RepositoryUsage{
+			Repository: repository,
+			TotalRuns:  max(workflowRuns.TotalCount, int64(len(workflowRuns.WorkflowRuns))),
+		}
+		repositoryUsage.Truncated = repositoryUsage.TotalRuns > int64(len(workflowRuns.WorkflowRuns))
+		output.TotalRuns += repositoryUsage.TotalRuns
+		output.Truncated = output.Truncated || repositoryUsage.Truncated
+
+		var workflows = make(map[int64]*WorkflowUsage)
+		for i, workflowRun := range workflowRuns.WorkflowRuns {
+			workflow, ok := workflows[workflowRun.WorkflowID]
+			if !ok {
+				workflow = &WorkflowUsage{
+					Repository:     repository,
+					WorkflowID:     workflowRun.WorkflowID,
+					Workflow:       workflowRun.Name,
+					BillableMSByOS: make(map[string]int64),
+				}
+				workflows[workflowRun.WorkflowID] = workflow
+			}
+
+			runTiming := runTimings[i]
+			workflow.Runs++
+			workflow.RunDurationMS += runTiming.RunDurationMS
+			repositoryUsage.Runs++
+			repositoryUsage.RunDurationMS += runTiming.RunDurationMS
+
+			for os, billable := range runTiming.Billable {
+				workflow.BillableMS += billable.TotalMS
+				workflow.BillableMSByOS[os] += billable.TotalMS
+				repositoryUsage.BillableMS += billable.TotalMS
+
+				if _, ok := operatingSystems[os]; !ok {
+					operatingSystems[os] = &OSUsage{OS: os}
+				}
+				operatingSystems[os].Jobs += billable.Jobs
+				operatingSystems[os].BillableMS += billable.TotalMS
+			}
+		}
+
+		for _, workflow := range workflows {
+			workflowTiming, err := u.githubRepository.GetWorkflowTiming(ctx, repository, workflow.WorkflowID)
+			if err != nil {
+				return nil, err
+			}
+			for _, billable := range workflowTiming.Billable {
+				workflow.BillingCycleMS += billable.TotalMS
+			}
+
+			output.Workflows = append(output.Workflows, *workflow)
+		}
+		output.Repositories = append(output.Repositories, repositoryUsage)
+	}
+
+	for _, osUsage := range operatingSystems {
+		output.OperatingSystems = append(output.OperatingSystems, *osUsage)
+	}
+
+	sort.Slice(output.Repositories, func(i, j int) bool {
+		return output.Repositories[i].BillableMS > output.Repositories[j].BillableMS
+	})
+	sort.Slice(output.Workflows, func(i, j int) bool {
+		if output.Workflows[i].BillableMS != output.Workflows[j].BillableMS {
+			return output.Workflows[i].BillableMS > output.Workflows[j].BillableMS
+		}
+		return output.Workflows[i].Workflow < output.Workflows[j].Workflow
+	})
+	sort.Slice(output.OperatingSystems, func(i, j int) bool {
+		if output.OperatingSystems[i].BillableMS != output.OperatingSystems[j].BillableMS {
+			return output.OperatingSystems[i].BillableMS > output.OperatingSystems[j].BillableMS
+		}
+		return output.OperatingSystems[i].OS < output.OperatingSystems[j].OS
+	})
+
+	return &output, nil
+}
+
+// getRunTimings gets the timings of the runs in their order, the first failure cancels the rest
+func (u useCase) getRunTimings(ctx context.Context, repository string, workflowRuns []gr.WorkflowRun) ([]gr.RunTiming, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var runTimings = make([]gr.RunTiming, len(workflowRuns))
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var semaphore = make(chan struct{}, usageConcurrency)
+
+	for i, workflowRun := range workflowRuns {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, runId int64) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			runTiming, err := u.githubRepository.GetWorkflowRunTiming(ctx, repository, runId)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			runTimings[i] = *runTiming
+		}(i, workflowRun.ID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return runTimings, nil
+}
+
+// WriteCSV writes the usage of the workflows in CSV format, with a column of billable minutes for every operating system.
+// The runs of the repository in the time window and whether some of them are not counted are repeated on every row.
+func (o *GetUsageOutput) WriteCSV(w io.Writer) error {
+	var repositories = make(map[string]RepositoryUsage)
+	for _, repositoryUsage := range o.Repositories {
+		repositories[repositoryUsage.Repository] = repositoryUsage
+	}
+
+	var header = []string{"repository", "workflow", "runs", "billable_minutes"}
+	for _, osUsage := range o.OperatingSystems {
+		header = append(header, strings.ToLower(osUsage.OS)+"_minutes")
+	}
+	header = append(header, "run_duration_minutes", "billing_cycle_minutes", "repository_total_runs", "repository_truncated")
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, workflow := range o.Workflows {
+		record := []string{
+			workflow.Repository,
+			workflow.Workflow,
+			strconv.Itoa(workflow.Runs),
+			FormatMinutes(workflow.BillableMS),
+		}
+		for _, osUsage := range o.OperatingSystems {
+			record = append(record, FormatMinutes(workflow.BillableMSByOS[osUsage.OS]))
+		}
+		repositoryUsage := repositories[workflow.Repository]
+		record = append(record, FormatMinutes(workflow.RunDurationMS), FormatMinutes(workflow.BillingCycleMS),
+			strconv.FormatInt(repositoryUsage.TotalRuns, 10), strconv.FormatBool(repositoryUsage.Truncated))
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the usage in indented JSON format
+func (o *GetUsageOutput) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(o)
+}
+
+// FormatMinutes formats milliseconds as minutes with a single decimal, like 12.5
+func FormatMinutes(ms int64) string {
+	return fmt.Sprintf("%.1f", float64(ms)/float64(time.Minute/time.Millisecond))
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// usageRepository has two runs of a linux workflow and a run of a workflow on both linux and macos
+type usageRepository struct {
+	gr.Repository
+
+	filter gr.WorkflowRunsFilter
+}
+
+func (r *usageRepository) ListWorkflowRuns(ctx context.Context, repository string, filter gr.WorkflowRunsFilter, limit int) (*gr.WorkflowRuns, error) {
+	r.filter = filter
+	return &gr.WorkflowRuns{TotalCount: 250, WorkflowRuns: []gr.WorkflowRun{
+		{ID: 1, WorkflowID: 10, Name: "Tests"},
+		{ID: 2, WorkflowID: 20, Name: "Release"},
+		{ID: 3, WorkflowID: 10, Name: "Tests"},
+	}}, nil
+}
+
+func (r *usageRepository) GetWorkflowRunTiming(ctx context.Context, repository string, runId int64) (*gr.RunTiming, error) {
+	if runId == 2 {
+		return &gr.RunTiming{
+			Billable: map[string]gr.RunBillable{
+				"UBUNTU": {TotalMS: 60000, Jobs: 1},
+				"MACOS":  {TotalMS: 600000, Jobs: 1},
+			},
+			RunDurationMS: 400000,
+		}, nil
+	}
+	return &gr.RunTiming{
+		Billable:      map[string]gr.RunBillable{"UBUNTU": {TotalMS: 120000, Jobs: 2}},
+		RunDurationMS: 90000,
+	}, nil
+}
+
+func (r *usageRepository) GetWorkflowTiming(ctx context.Context, repository string, workflowId int64) (*gr.WorkflowTiming, error) {
+	return &gr.WorkflowTiming{Billable: map[string]gr.WorkflowBillable{"UBUNTU": {TotalMS: workflowId * 60000}}}, nil
+}
+
+func TestUseCase_GetUsage(t *testing.T) {
+	githubRepository := &usageRepository{}
+
+	output, err := New(githubRepository).GetUsage(context.Background(), GetUsageInput{Repositories: []string{"owner/repo"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", githubRepository.filter.Status)
+	assert.False(t, githubRepository.filter.CreatedBefore.IsZero())
+
+	assert.Equal(t, []RepositoryUsage{
+		{Repository: "owner/repo", Runs: 3, TotalRuns: 250, Truncated: true, BillableMS: 900000, RunDurationMS: 580000},
+	}, output.Repositories)
+	assert.Equal(t, int64(250), output.TotalRuns)
+	assert.True(t, output.Truncated)
+	assert.Equal(t, []WorkflowUsage{
+		{
+			Repository: "owner/repo", WorkflowID: 20, Workflow: "Release", Runs: 1,
+			BillableMS: 660000, BillableMSByOS: map[string]int64{"UBUNTU": 60000, "MACOS": 600000},
+			RunDurationMS: 400000, BillingCycleMS: 1200000,
+		},
+		{
+			Repository: "owner/repo", WorkflowID: 10, Workflow: "Tests", Runs: 2,
+			BillableMS: 240000, BillableMSByOS: map[string]int64{"UBUNTU": 240000},
+			RunDurationMS: 180000, BillingCycleMS: 600000,
+		},
+	}, output.Workflows)
+	assert.Equal(t, []OSUsage{
+		{OS: "MACOS", Jobs: 1, BillableMS: 600000},
+		{OS: "UBUNTU", Jobs: 5, BillableMS: 300000},
+	}, output.OperatingSystems)
+
+	var csv bytes.Buffer
+	assert.NoError(t, output.WriteCSV(&csv))
+	assert.Equal(t, "repository,workflow,runs,billable_minutes,macos_minutes,ubuntu_minutes,run_duration_minutes,billing_cycle_minutes,repository_total_runs,repository_truncated\n"+
+		"owner/repo,Release,1,11.0,10.0,1.0,6.7,20.0,250,true\n"+
+		"owner/repo,Tests,2,4.0,0.0,4.0,3.0,10.0,250,true\n", csv.String())
+}
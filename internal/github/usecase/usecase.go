@@ -2,14 +2,42 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	gr "github.com/termkit/gama/internal/github/repository"
 	pw "github.com/termkit/gama/pkg/workflow"
+	pwl "github.com/termkit/gama/pkg/workflowlog"
 	py "github.com/termkit/gama/pkg/yaml"
 )
 
+// maxRepositoryWorkers is the number of repositories whose workflows are fetched concurrently,
+// more parallel requests trigger the secondary rate limits of GitHub
+const maxRepositoryWorkers = 8
+
+// maxWorkflowWorkers is the number of workflows whose events are fetched concurrently
+const maxWorkflowWorkers = 8
+
+// correlationInput is the workflow input that is filled with a generated id to find the run of a dispatch
+const correlationInput = "correlation_id"
+
+// dispatchedRunLookup is the number of recent runs that are searched for the run of a dispatch
+const dispatchedRunLookup = 20
+
+// Polls of the run of a dispatch, GitHub creates the run a few seconds after the dispatch
+var (
+	dispatchedRunInterval  = 2 * time.Second
+	dispatchedRunTimeout   = 30 * time.Second
+	dispatchedRunTolerance = 2 * time.Second // the times of GitHub have a precision of a second
+)
+
 type useCase struct {
 	githubRepository gr.Repository
 }
@@ -21,7 +49,7 @@ func New(githubRepository gr.Repository) UseCase {
 }
 
 func (u useCase) ListRepositories(ctx context.Context, input ListRepositoriesInput) (*ListRepositoriesOutput, error) {
-	repositories, err := u.githubRepository.ListRepositories(ctx, input.Limit)
+	repositories, err := u.collectRepositories(ctx, input.Sources, input.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +60,7 @@ func (u useCase) ListRepositories(ctx context.Context, input ListRepositoriesInp
 	errors := make(chan error, len(repositories))
 
 	// Start a number of workers
-	for w := 1; w <= len(repositories); w++ {
+	for w := 1; w <= min(len(repositories), maxRepositoryWorkers); w++ {
 		go u.workerListRepositories(ctx, jobs, results, errors)
 	}
 
@@ -59,6 +87,67 @@ func (u useCase) ListRepositories(ctx context.Context, input ListRepositoriesInp
 	}, resultErr
 }
 
+// collectRepositories lists the repositories of every source in order, without duplicates and up to the limit
+func (u useCase) collectRepositories(ctx context.Context, sources RepositorySources, limit int) ([]gr.GithubRepository, error) {
+	var repositories []gr.GithubRepository
+	var seen = make(map[string]bool)
+	add := func(found []gr.GithubRepository) bool {
+		for _, repository := range found {
+			// Names of repositories are case-insensitive, the included ones may be written differently
+			var name = strings.ToLower(repository.FullName)
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			repositories = append(repositories, repository)
+			if limit > 0 && len(repositories) >= limit {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !sources.SkipUser {
+		userRepositories, err := u.githubRepository.ListRepositories(ctx, gr.RepositoriesFilter{
+			Affiliation: sources.Affiliation,
+			Visibility:  sources.Visibility,
+		}, limit)
+		if err != nil {
+			return nil, err
+		}
+		if !add(userRepositories) {
+			return repositories, nil
+		}
+	}
+
+	for _, organization := range sources.Organizations {
+		organizationRepositories, err := u.githubRepository.ListOrganizationRepositories(ctx, organization, limit)
+		if err != nil {
+			return nil, fmt.Errorf("repositories of organization %s cannot be listed: %w", organization, err)
+		}
+		if !add(organizationRepositories) {
+			return repositories, nil
+		}
+	}
+
+	for _, name := range sources.Include {
+		if seen[strings.ToLower(name)] {
+			continue
+		}
+
+		repository, err := u.githubRepository.GetRepository(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("repository %s cannot be fetched: %w", name, err)
+		}
+		if !add([]gr.GithubRepository{*repository}) {
+			return repositories, nil
+		}
+	}
+
+	return repositories, nil
+}
+
 func (u useCase) workerListRepositories(ctx context.Context, jobs <-chan gr.GithubRepository, results chan<- GithubRepository, errors chan<- error) {
 	for repository := range jobs {
 		getWorkflows, err := u.githubRepository.GetWorkflows(ctx, repository.FullName)
@@ -79,11 +168,49 @@ func (u useCase) workerListRepositories(ctx context.Context, jobs <-chan gr.Gith
 			Stars:         repository.StargazersCount,
 			Private:       repository.Private,
 			DefaultBranch: repository.DefaultBranch,
+			HTMLURL:       u.githubRepository.WebURL() + "/" + repository.FullName,
 			Workflows:     workflows,
 		}
 	}
 }
 
+func (u useCase) GetRepository(ctx context.Context, input GetRepositoryInput) (*GetRepositoryOutput, error) {
+	repository, err := u.githubRepository.GetRepository(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetRepositoryOutput{
+		Repository: GithubRepository{
+			Name:          repository.FullName,
+			Stars:         repository.StargazersCount,
+			Private:       repository.Private,
+			DefaultBranch: repository.DefaultBranch,
+			HTMLURL:       u.githubRepository.WebURL() + "/" + repository.FullName,
+		},
+	}, nil
+}
+
+func (u useCase) ListBranches(ctx context.Context, input ListBranchesInput) (*ListBranchesOutput, error) {
+	branches, err := u.githubRepository.ListBranches(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []GithubBranch
+	for _, branch := range branches {
+		result = append(result, GithubBranch{
+			Name:      branch.Name,
+			Protected: branch.Protected,
+			CommitSHA: branch.Commit.SHA,
+		})
+	}
+
+	return &ListBranchesOutput{
+		Branches: result,
+	}, nil
+}
+
 func (u useCase) GetWorkflowHistory(ctx context.Context, input GetWorkflowHistoryInput) (*GetWorkflowHistoryOutput, error) {
 	var targetRepositoryName = input.Repository
 	var targetBranch = input.Branch
@@ -95,7 +222,17 @@ func (u useCase) GetWorkflowHistory(ctx context.Context, input GetWorkflowHistor
 		targetBranch = repository.DefaultBranch
 	}
 
-	workflowRuns, err := u.githubRepository.ListWorkflowRuns(ctx, targetRepositoryName, targetBranch)
+	workflowRuns, err := u.githubRepository.ListWorkflowRuns(ctx, targetRepositoryName, gr.WorkflowRunsFilter{
+		Workflow:            input.Filter.Workflow,
+		Branch:              targetBranch,
+		Event:               input.Filter.Event,
+		Actor:               input.Filter.Actor,
+		Status:              input.Filter.Status,
+		HeadSHA:             input.Filter.HeadSHA,
+		CreatedAfter:        input.Filter.CreatedAfter,
+		CreatedBefore:       input.Filter.CreatedBefore,
+		ExcludePullRequests: input.Filter.ExcludePullRequests,
+	}, input.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +248,7 @@ func (u useCase) GetWorkflowHistory(ctx context.Context, input GetWorkflowHistor
 			Status:       workflowRun.Status,
 			Conclusion:   workflowRun.Conclusion,
 			Duration:     u.getDuration(workflowRun.CreatedAt, workflowRun.UpdatedAt, workflowRun.Status),
+			HTMLURL:      fmt.Sprintf("%s/%s/actions/runs/%d", u.githubRepository.WebURL(), targetRepositoryName, workflowRun.ID),
 		})
 	}
 
@@ -119,9 +257,250 @@ func (u useCase) GetWorkflowHistory(ctx context.Context, input GetWorkflowHistor
 	}, nil
 }
 
+func (u useCase) ListJobsForRun(ctx context.Context, input ListJobsForRunInput) (*ListJobsForRunOutput, error) {
+	workflowJobs, err := u.githubRepository.ListJobsForRun(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	for _, workflowJob := range workflowJobs {
+		jobs = append(jobs, u.toJob(workflowJob))
+	}
+
+	return &ListJobsForRunOutput{
+		Jobs: jobs,
+	}, nil
+}
+
+func (u useCase) GetJob(ctx context.Context, input GetJobInput) (*GetJobOutput, error) {
+	workflowJob, err := u.githubRepository.GetJob(ctx, input.Repository, input.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetJobOutput{
+		Job: u.toJob(*workflowJob),
+	}, nil
+}
+
+func (u useCase) GetPendingDeployments(ctx context.Context, input GetPendingDeploymentsInput) (*GetPendingDeploymentsOutput, error) {
+	pendingDeployments, err := u.githubRepository.GetPendingDeployments(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []PendingDeployment
+	for _, pendingDeployment := range pendingDeployments {
+		var reviewers []string
+		for _, reviewer := range pendingDeployment.Reviewers {
+			if reviewer.Type == "Team" {
+				reviewers = append(reviewers, reviewer.Reviewer.Name)
+			} else {
+				reviewers = append(reviewers, reviewer.Reviewer.Login)
+			}
+		}
+
+		deployments = append(deployments, PendingDeployment{
+			EnvironmentID:   pendingDeployment.Environment.ID,
+			EnvironmentName: pendingDeployment.Environment.Name,
+			CanApprove:      pendingDeployment.CurrentUserCanApprove,
+			Reviewers:       reviewers,
+			WaitTimer:       pendingDeployment.WaitTimer,
+		})
+	}
+
+	return &GetPendingDeploymentsOutput{
+		Deployments: deployments,
+	}, nil
+}
+
+func (u useCase) ReviewPendingDeployments(ctx context.Context, input ReviewPendingDeploymentsInput) (*ReviewPendingDeploymentsOutput, error) {
+	var state = "rejected"
+	if input.Approve {
+		state = "approved"
+	}
+
+	err := u.githubRepository.ReviewPendingDeployments(ctx, input.Repository, input.WorkflowID, gr.DeploymentReview{
+		EnvironmentIDs: input.EnvironmentIDs,
+		State:          state,
+		Comment:        input.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewPendingDeploymentsOutput{}, nil
+}
+
+func (u useCase) GetJobLogs(ctx context.Context, input GetJobLogsInput) (*GetJobLogsOutput, error) {
+	job, err := u.githubRepository.GetJob(ctx, input.Repository, input.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := u.githubRepository.GetJobLogs(ctx, input.Repository, input.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetJobLogsOutput{
+		Log: JobLog{
+			JobName: job.Name,
+			Lines:   pwl.Parse(content),
+		},
+	}, nil
+}
+
+func (u useCase) GetWorkflowRunLogs(ctx context.Context, input GetWorkflowRunLogsInput) (*GetWorkflowRunLogsOutput, error) {
+	archive, err := u.githubRepository.GetWorkflowRunLogs(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	// Stream the archive to a temporary file, the files of a zip are read from its end
+	file, err := os.CreateTemp("", "gama-logs-*.zip")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	size, err := io.Copy(file, archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download logs archive: %w", err)
+	}
+
+	files, err := pwl.Unzip(file, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs archive: %w", err)
+	}
+
+	var logs []JobLog
+	for _, file := range files {
+		logs = append(logs, JobLog{
+			JobName: file.Name,
+			Lines:   pwl.Parse(file.Content),
+		})
+	}
+
+	return &GetWorkflowRunLogsOutput{
+		Logs: logs,
+	}, nil
+}
+
+func (u useCase) toJob(workflowJob gr.WorkflowJob) Job {
+	var steps []Step
+	for _, workflowStep := range workflowJob.Steps {
+		steps = append(steps, Step{
+			Number:     workflowStep.Number,
+			Name:       workflowStep.Name,
+			Status:     workflowStep.Status,
+			Conclusion: workflowStep.Conclusion,
+			Duration:   u.getStepDuration(workflowStep.StartedAt, workflowStep.CompletedAt, workflowStep.Status),
+		})
+	}
+
+	return Job{
+		ID:         workflowJob.ID,
+		Name:       workflowJob.Name,
+		Status:     workflowJob.Status,
+		Conclusion: workflowJob.Conclusion,
+		RunnerName: workflowJob.RunnerName,
+		Labels:     workflowJob.Labels,
+		StartedAt:  u.timeToString(workflowJob.StartedAt),
+		Duration:   u.getStepDuration(workflowJob.StartedAt, workflowJob.CompletedAt, workflowJob.Status),
+		HTMLURL:    workflowJob.HTMLURL,
+		Steps:      steps,
+	}
+}
+
+func (u useCase) ListWorkflows(ctx context.Context, input ListWorkflowsInput) (*ListWorkflowsOutput, error) {
+	repositoryWorkflows, err := u.githubRepository.GetWorkflows(ctx, input.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := u.getWorkflowEvents(ctx, input.Repository, input.Branch, repositoryWorkflows)
+	if err != nil {
+		return nil, err
+	}
+
+	var workflows []RepositoryWorkflow
+	for i, workflow := range repositoryWorkflows {
+		workflows = append(workflows, RepositoryWorkflow{
+			ID:     workflow.ID,
+			Name:   workflow.Name,
+			Path:   workflow.Path,
+			State:  workflow.State,
+			Events: events[i],
+		})
+	}
+
+	return &ListWorkflowsOutput{
+		Workflows: workflows,
+	}, nil
+}
+
+// getWorkflowEvents gets the events of the workflows in their order, the first failure cancels the rest
+func (u useCase) getWorkflowEvents(ctx context.Context, repository string, branch string, workflows []gr.Workflow) ([][]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var events = make([][]string, len(workflows))
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var semaphore = make(chan struct{}, maxWorkflowWorkers)
+
+	for i, workflow := range workflows {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, workflowPath string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			// The workflow may not exist on the given branch, it is listed without its events
+			workflowEvents, err := u.githubRepository.GetWorkflowEvents(ctx, repository, branch, workflowPath)
+			if err != nil && !gr.IsNotFound(err) {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			events[i] = workflowEvents
+		}(i, workflow.Path)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return events, nil
+}
+
+func (u useCase) EnableWorkflow(ctx context.Context, input EnableWorkflowInput) (*EnableWorkflowOutput, error) {
+	if err := u.githubRepository.EnableWorkflow(ctx, input.Repository, input.WorkflowID); err != nil {
+		return nil, err
+	}
+	return &EnableWorkflowOutput{}, nil
+}
+
+func (u useCase) DisableWorkflow(ctx context.Context, input DisableWorkflowInput) (*DisableWorkflowOutput, error) {
+	if err := u.githubRepository.DisableWorkflow(ctx, input.Repository, input.WorkflowID); err != nil {
+		return nil, err
+	}
+	return &DisableWorkflowOutput{}, nil
+}
+
 func (u useCase) GetTriggerableWorkflows(ctx context.Context, input GetTriggerableWorkflowsInput) (*GetTriggerableWorkflowsOutput, error) {
-	// TODO: Add branch option
-	triggerableWorkflows, err := u.githubRepository.GetTriggerableWorkflows(ctx, input.Repository)
+	triggerableWorkflows, err := u.githubRepository.GetTriggerableWorkflows(ctx, input.Repository, input.Branch)
 	if err != nil {
 		return nil, err
 	}
@@ -164,12 +543,168 @@ func (u useCase) InspectWorkflow(ctx context.Context, input InspectWorkflowInput
 }
 
 func (u useCase) TriggerWorkflow(ctx context.Context, input TriggerWorkflowInput) (*TriggerWorkflowOutput, error) {
-	err := u.githubRepository.TriggerWorkflow(ctx, input.Repository, input.Branch, input.WorkflowFile, input.Content)
+	dispatched, err := u.DispatchWorkflow(ctx, DispatchWorkflowInput{
+		Repository:   input.Repository,
+		Branch:       input.Branch,
+		WorkflowFile: input.WorkflowFile,
+		Content:      input.Content,
+	})
+	if err != nil {
+		return nil, err
+	} else if input.NoWait {
+		return &TriggerWorkflowOutput{}, nil
+	}
+
+	// The dispatch API does not return the run, a run that is not created in time leaves the output empty
+	found, err := u.FindDispatchedRun(ctx, FindDispatchedRunInput{
+		Repository:    input.Repository,
+		Branch:        input.Branch,
+		WorkflowFile:  input.WorkflowFile,
+		DispatchedAt:  dispatched.DispatchedAt,
+		CorrelationID: dispatched.CorrelationID,
+	})
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if err != nil {
+		return nil, fmt.Errorf("the workflow is triggered, but its run cannot be found: %w", err)
+	}
+
+	return &TriggerWorkflowOutput{
+		RunID:   found.RunID,
+		HTMLURL: found.HTMLURL,
+		Status:  found.Status,
+	}, nil
+}
+
+func (u useCase) DispatchWorkflow(ctx context.Context, input DispatchWorkflowInput) (*DispatchWorkflowOutput, error) {
+	content, correlationID, err := withCorrelationID(input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	dispatchedAt, err := u.githubRepository.TriggerWorkflow(ctx, input.Repository, input.Branch, input.WorkflowFile, content)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TriggerWorkflowOutput{}, nil
+	return &DispatchWorkflowOutput{
+		DispatchedAt:  dispatchedAt,
+		CorrelationID: correlationID,
+	}, nil
+}
+
+func (u useCase) FindDispatchedRun(ctx context.Context, input FindDispatchedRunInput) (*FindDispatchedRunOutput, error) {
+	// The runs of the dispatch are filtered by the actor, the response of /user is cached.
+	// Without the user, the run is matched by its creation time and the correlation id.
+	var actor string
+	if user, err := u.githubRepository.GetAuthenticatedUser(ctx); err == nil {
+		actor = user.Login
+	}
+
+	workflowRun, err := u.findDispatchedRun(ctx, input, actor)
+	if err != nil {
+		return nil, err
+	} else if workflowRun == nil {
+		return &FindDispatchedRunOutput{}, nil
+	}
+
+	return &FindDispatchedRunOutput{
+		RunID:   workflowRun.ID,
+		HTMLURL: fmt.Sprintf("%s/%s/actions/runs/%d", u.githubRepository.WebURL(), input.Repository, workflowRun.ID),
+		Status:  workflowRun.Status,
+	}, nil
+}
+
+// findDispatchedRun waits for the workflow_dispatch run of the actor on the branch that is created after the dispatch.
+// If the workflow has a correlation_id input, the run is matched by the generated id in its name as well.
+// The runs of every actor are searched if the actor is empty.
+func (u useCase) findDispatchedRun(ctx context.Context, input FindDispatchedRunInput, actor string) (*gr.WorkflowRun, error) {
+	createdAfter := input.DispatchedAt.Add(-dispatchedRunTolerance)
+	deadline := time.Now().Add(dispatchedRunTimeout)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(dispatchedRunInterval):
+		}
+
+		workflowRuns, err := u.githubRepository.ListWorkflowRuns(ctx, input.Repository, gr.WorkflowRunsFilter{
+			Workflow:            input.WorkflowFile,
+			Branch:              input.Branch,
+			Event:               "workflow_dispatch",
+			Actor:               actor,
+			CreatedAfter:        createdAfter,
+			ExcludePullRequests: true,
+		}, dispatchedRunLookup)
+		if err != nil {
+			return nil, err
+		}
+
+		if workflowRun := matchDispatchedRun(workflowRuns.WorkflowRuns, createdAfter, input.CorrelationID); workflowRun != nil {
+			return workflowRun, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// matchDispatchedRun returns the earliest run created after the time, whose name contains the correlation id if it is set
+func matchDispatchedRun(workflowRuns []gr.WorkflowRun, createdAfter time.Time, correlationID string) *gr.WorkflowRun {
+	var match *gr.WorkflowRun
+	for i, workflowRun := range workflowRuns {
+		if workflowRun.CreatedAt.Before(createdAfter) {
+			continue
+		}
+		if correlationID != "" && !strings.Contains(workflowRun.DisplayTitle, correlationID) && !strings.Contains(workflowRun.Name, correlationID) {
+			continue
+		}
+		if match == nil || workflowRun.CreatedAt.Before(match.CreatedAt) {
+			match = &workflowRuns[i]
+		}
+	}
+	return match
+}
+
+// withCorrelationID fills the empty correlation_id input of a workflow with a generated id.
+// Workflows that put the input in their run-name can be told apart from the runs dispatched at the same time.
+func withCorrelationID(content string) (string, string, error) {
+	var inputs map[string]any
+	if err := json.Unmarshal([]byte(content), &inputs); err != nil {
+		return "", "", fmt.Errorf("failed to parse workflow inputs: %w", err)
+	}
+
+	if value, ok := inputs[correlationInput]; !ok || value != "" {
+		return content, "", nil
+	}
+
+	var id = make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	correlationID := hex.EncodeToString(id)
+	inputs[correlationInput] = correlationID
+
+	modifiedContent, err := json.Marshal(inputs)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(modifiedContent), correlationID, nil
+}
+
+func (u useCase) GetWorkflowRun(ctx context.Context, input GetWorkflowRunInput) (*GetWorkflowRunOutput, error) {
+	workflowRun, err := u.githubRepository.GetWorkflowRun(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetWorkflowRunOutput{
+		Status:     workflowRun.Status,
+		Conclusion: workflowRun.Conclusion,
+		Attempt:    workflowRun.RunAttempt,
+		HTMLURL:    workflowRun.HTMLURL,
+	}, nil
 }
 
 func (u useCase) ReRunFailedJobs(ctx context.Context, input ReRunFailedJobsInput) (*ReRunFailedJobsOutput, error) {
@@ -193,10 +728,37 @@ func (u useCase) CancelWorkflow(ctx context.Context, input CancelWorkflowInput)
 	return &CancelWorkflowOutput{}, nil
 }
 
+func (u useCase) GetRateLimit(ctx context.Context, input GetRateLimitInput) (*GetRateLimitOutput, error) {
+	// Use the rate limit of the last response, ask for it if no request is made yet
+	rateLimit := u.githubRepository.RateLimit()
+	if rateLimit == nil {
+		var err error
+		rateLimit, err = u.githubRepository.GetRateLimit(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &GetRateLimitOutput{
+		Limit:     rateLimit.Limit,
+		Remaining: rateLimit.Remaining,
+		Reset:     rateLimit.Reset,
+	}, nil
+}
+
 func (u useCase) timeToString(t time.Time) string {
 	return t.In(time.Local).Format("2006-01-02 15:04:05")
 }
 
+// getStepDuration returns the duration of a job or a step, which is empty until it starts
+func (u useCase) getStepDuration(startTime time.Time, endTime time.Time, status string) string {
+	if startTime.IsZero() || status == "queued" || status == "waiting" || status == "pending" {
+		return ""
+	}
+
+	return u.getDuration(startTime, endTime, status)
+}
+
 func (u useCase) getDuration(startTime time.Time, endTime time.Time, status string) string {
 	if status != "completed" {
 		return "running"
@@ -2,8 +2,14 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/termkit/gama/internal/github/repository"
 	pkgconfig "github.com/termkit/gama/pkg/config"
 )
@@ -86,3 +92,248 @@ func TestUseCase_TriggerWorkflow(t *testing.T) {
 	}
 	t.Log(trigger)
 }
+
+// dispatchRepository creates a run for every dispatch, with the correlation id in its name if it is given
+type dispatchRepository struct {
+	repository.Repository
+
+	dispatchedAt time.Time
+	otherRuns    []repository.WorkflowRun
+	content      string
+	userErr      error
+	listErr      error
+	lookups      int
+	actor        string // actor filter of the last lookup
+}
+
+func (r *dispatchRepository) WebURL() string { return "https://github.com" }
+
+func (r *dispatchRepository) GetAuthenticatedUser(ctx context.Context) (*repository.GithubUser, error) {
+	if r.userErr != nil {
+		return nil, r.userErr
+	}
+	return &repository.GithubUser{Login: "octocat"}, nil
+}
+
+func (r *dispatchRepository) TriggerWorkflow(ctx context.Context, repo string, branch string, workflowName string, workflow any) (time.Time, error) {
+	r.content = workflow.(string)
+	return r.dispatchedAt, nil
+}
+
+func (r *dispatchRepository) ListWorkflowRuns(ctx context.Context, repo string, filter repository.WorkflowRunsFilter, limit int) (*repository.WorkflowRuns, error) {
+	r.lookups++
+	r.actor = filter.Actor
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+
+	var inputs map[string]string
+	if err := json.Unmarshal([]byte(r.content), &inputs); err != nil {
+		return nil, err
+	}
+
+	dispatchedRun := repository.WorkflowRun{
+		ID:           42,
+		Status:       "queued",
+		DisplayTitle: strings.TrimSpace("Deploy " + inputs[correlationInput]),
+		CreatedAt:    r.dispatchedAt.Add(time.Second),
+	}
+
+	return &repository.WorkflowRuns{WorkflowRuns: append(r.otherRuns, dispatchedRun)}, nil
+}
+
+func TestUseCase_TriggerWorkflow_FindsDispatchedRun(t *testing.T) {
+	dispatchedRunInterval = time.Millisecond
+	defer func() { dispatchedRunInterval = 2 * time.Second }()
+
+	dispatchedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("by creation time", func(t *testing.T) {
+		githubRepository := &dispatchRepository{
+			dispatchedAt: dispatchedAt,
+			otherRuns: []repository.WorkflowRun{
+				{ID: 41, Status: "completed", CreatedAt: dispatchedAt.Add(-time.Minute)},
+			},
+		}
+
+		trigger, err := New(githubRepository).TriggerWorkflow(context.Background(), TriggerWorkflowInput{
+			Repository: "owner/repo", Branch: "main", WorkflowFile: ".github/workflows/deploy.yml", Content: `{"environment": "production"}`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), trigger.RunID)
+		assert.Equal(t, "https://github.com/owner/repo/actions/runs/42", trigger.HTMLURL)
+		assert.Equal(t, "queued", trigger.Status)
+	})
+
+	t.Run("by correlation id", func(t *testing.T) {
+		githubRepository := &dispatchRepository{
+			dispatchedAt: dispatchedAt,
+			otherRuns: []repository.WorkflowRun{
+				// dispatched at the same time by another session
+				{ID: 40, Status: "queued", DisplayTitle: "Deploy 0123456789abcdef", CreatedAt: dispatchedAt},
+			},
+		}
+
+		trigger, err := New(githubRepository).TriggerWorkflow(context.Background(), TriggerWorkflowInput{
+			Repository: "owner/repo", Branch: "main", WorkflowFile: ".github/workflows/deploy.yml", Content: `{"correlation_id": ""}`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), trigger.RunID)
+		assert.NotContains(t, githubRepository.content, `"correlation_id":""`)
+	})
+
+	t.Run("without the user", func(t *testing.T) {
+		githubRepository := &dispatchRepository{
+			dispatchedAt: dispatchedAt,
+			userErr:      errors.New("proxy error"),
+		}
+
+		trigger, err := New(githubRepository).TriggerWorkflow(context.Background(), TriggerWorkflowInput{
+			Repository: "owner/repo", Branch: "main", WorkflowFile: ".github/workflows/deploy.yml", Content: `{}`,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), trigger.RunID)
+		assert.Empty(t, githubRepository.actor, "the runs of every actor are searched")
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		githubRepository := &dispatchRepository{
+			dispatchedAt: dispatchedAt,
+			listErr:      &repository.APIError{StatusCode: http.StatusForbidden, Message: "Resource not accessible by integration"},
+		}
+
+		_, err := New(githubRepository).TriggerWorkflow(context.Background(), TriggerWorkflowInput{
+			Repository: "owner/repo", Branch: "main", WorkflowFile: ".github/workflows/deploy.yml", Content: `{}`,
+		})
+		assert.ErrorContains(t, err, "the workflow is triggered")
+		assert.ErrorIs(t, err, githubRepository.listErr)
+	})
+
+	t.Run("no wait", func(t *testing.T) {
+		githubRepository := &dispatchRepository{dispatchedAt: dispatchedAt}
+
+		trigger, err := New(githubRepository).TriggerWorkflow(context.Background(), TriggerWorkflowInput{
+			Repository: "owner/repo", Branch: "main", WorkflowFile: ".github/workflows/deploy.yml", Content: `{}`, NoWait: true,
+		})
+		assert.NoError(t, err)
+		assert.Zero(t, trigger.RunID)
+		assert.Zero(t, githubRepository.lookups)
+	})
+}
+
+func TestWithCorrelationID(t *testing.T) {
+	content, correlationID, err := withCorrelationID(`{"environment": "production"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"environment": "production"}`, content)
+	assert.Empty(t, correlationID)
+
+	content, correlationID, err = withCorrelationID(`{"environment": "production", "correlation_id": ""}`)
+	assert.NoError(t, err)
+	assert.Len(t, correlationID, 16)
+	assert.JSONEq(t, `{"environment": "production", "correlation_id": "`+correlationID+`"}`, content)
+
+	// A value given by the user is kept
+	content, correlationID, err = withCorrelationID(`{"correlation_id": "release-1"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"correlation_id": "release-1"}`, content)
+	assert.Empty(t, correlationID)
+}
+
+// workflowsRepository has a scheduled workflow, and a disabled workflow that is deleted from the branch
+type workflowsRepository struct {
+	repository.Repository
+}
+
+func (r *workflowsRepository) GetWorkflows(ctx context.Context, repo string) ([]repository.Workflow, error) {
+	return []repository.Workflow{
+		{ID: 1, Name: "Nightly", Path: ".github/workflows/nightly.yml", State: "active"},
+		{ID: 2, Name: "Legacy", Path: ".github/workflows/legacy.yml", State: "disabled_manually"},
+	}, nil
+}
+
+func (r *workflowsRepository) GetWorkflowEvents(ctx context.Context, repo string, branch string, workflowPath string) ([]string, error) {
+	if workflowPath == ".github/workflows/legacy.yml" {
+		return nil, &repository.APIError{StatusCode: http.StatusNotFound}
+	}
+	return []string{"schedule", "workflow_dispatch"}, nil
+}
+
+func TestUseCase_ListWorkflows(t *testing.T) {
+	u := New(&workflowsRepository{})
+
+	output, err := u.ListWorkflows(context.Background(), ListWorkflowsInput{Repository: "owner/repo"})
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryWorkflow{
+		{ID: 1, Name: "Nightly", Path: ".github/workflows/nightly.yml", State: "active", Events: []string{"schedule", "workflow_dispatch"}},
+		{ID: 2, Name: "Legacy", Path: ".github/workflows/legacy.yml", State: "disabled_manually"},
+	}, output.Workflows)
+}
+
+// sourcesRepository has a repository of the user that is in an organization as well, and a repository of another owner
+type sourcesRepository struct {
+	repository.Repository
+
+	filter repository.RepositoriesFilter
+}
+
+func (r *sourcesRepository) WebURL() string {
+	return "https://github.com"
+}
+
+func (r *sourcesRepository) ListRepositories(ctx context.Context, filter repository.RepositoriesFilter, limit int) ([]repository.GithubRepository, error) {
+	r.filter = filter
+	return []repository.GithubRepository{{FullName: "octocat/tools"}, {FullName: "termkit/gama"}}, nil
+}
+
+func (r *sourcesRepository) ListOrganizationRepositories(ctx context.Context, organization string, limit int) ([]repository.GithubRepository, error) {
+	return []repository.GithubRepository{{FullName: "termkit/gama"}, {FullName: "termkit/docs"}}, nil
+}
+
+func (r *sourcesRepository) GetRepository(ctx context.Context, repo string) (*repository.GithubRepository, error) {
+	return &repository.GithubRepository{FullName: repo}, nil
+}
+
+func (r *sourcesRepository) GetWorkflows(ctx context.Context, repo string) ([]repository.Workflow, error) {
+	return nil, nil
+}
+
+func TestUseCase_ListRepositories_Sources(t *testing.T) {
+	names := func(output *ListRepositoriesOutput) []string {
+		var names []string
+		for _, repository := range output.Repositories {
+			names = append(names, repository.Name)
+		}
+		return names
+	}
+
+	t.Run("deduplicated", func(t *testing.T) {
+		githubRepository := &sourcesRepository{}
+
+		output, err := New(githubRepository).ListRepositories(context.Background(), ListRepositoriesInput{Sources: RepositorySources{
+			Affiliation:   "owner",
+			Visibility:    "all",
+			Organizations: []string{"termkit"},
+			Include:       []string{"Termkit/Gama", "charmbracelet/bubbletea"},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, repository.RepositoriesFilter{Affiliation: "owner", Visibility: "all"}, githubRepository.filter)
+		assert.ElementsMatch(t, []string{"octocat/tools", "termkit/gama", "termkit/docs", "charmbracelet/bubbletea"}, names(output))
+	})
+
+	t.Run("without the user's repositories", func(t *testing.T) {
+		output, err := New(&sourcesRepository{}).ListRepositories(context.Background(), ListRepositoriesInput{Sources: RepositorySources{
+			SkipUser:      true,
+			Organizations: []string{"termkit"},
+		}})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"termkit/gama", "termkit/docs"}, names(output))
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		output, err := New(&sourcesRepository{}).ListRepositories(context.Background(), ListRepositoriesInput{Limit: 3, Sources: RepositorySources{
+			Organizations: []string{"termkit"},
+		}})
+		assert.NoError(t, err)
+		assert.Len(t, output.Repositories, 3)
+	})
+}
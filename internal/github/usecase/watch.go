@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// Intervals between the polls of a watched run
+var (
+	watchMinInterval    = 3 * time.Second
+	watchQueuedInterval = 10 * time.Second
+	watchMaxInterval    = 30 * time.Second
+)
+
+// watchStartTimeout is how long a re-run may take to start, the run shows its previous attempt until then
+var watchStartTimeout = 2 * time.Minute
+
+// RunNotStartedError is sent by WatchRun if a re-run does not start in time, like when the re-run request is dropped
+type RunNotStartedError struct {
+	RunID           int64
+	PreviousAttempt int
+	Timeout         time.Duration
+}
+
+func (e *RunNotStartedError) Error() string {
+	return fmt.Sprintf("run %d is not re-run in %s, it is still at attempt %d", e.RunID, e.Timeout, e.PreviousAttempt)
+}
+
+// IsRunNotStarted reports whether err is caused by a re-run that does not start in time
+func IsRunNotStarted(err error) bool {
+	var runNotStartedError *RunNotStartedError
+	return errors.As(err, &runNotStartedError)
+}
+
+func (u useCase) WatchRun(ctx context.Context, input WatchRunInput) (*WatchRunOutput, error) {
+	updates := make(chan RunUpdate)
+
+	go u.watchRun(ctx, input, updates)
+
+	return &WatchRunOutput{
+		Updates: updates,
+	}, nil
+}
+
+// watchRun polls the run and its jobs, and sends an update whenever something changes until the run completes.
+// A re-run starts a moment after it is requested, the completed previous attempt is not sent until then,
+// and the watch ends with a RunNotStartedError if the re-run does not start in watchStartTimeout.
+func (u useCase) watchRun(ctx context.Context, input WatchRunInput, updates chan<- RunUpdate) {
+	defer close(updates)
+
+	var startDeadline = time.Now().Add(watchStartTimeout)
+	var previous *RunUpdate
+	var interval = watchMinInterval
+	for {
+		current, err := u.pollRun(ctx, input)
+		if err != nil {
+			if ctx.Err() != nil || !sendRunUpdate(ctx, updates, RunUpdate{Err: err}) || gr.IsNotFound(err) {
+				return
+			}
+			interval = min(interval*2, watchMaxInterval)
+		} else if input.PreviousAttempt > 0 && current.Status == "completed" && current.Attempt <= input.PreviousAttempt {
+			if time.Now().After(startDeadline) {
+				sendRunUpdate(ctx, updates, RunUpdate{Err: &RunNotStartedError{
+					RunID:           input.WorkflowID,
+					PreviousAttempt: input.PreviousAttempt,
+					Timeout:         watchStartTimeout,
+				}})
+				return
+			}
+			interval = watchMinInterval
+		} else {
+			changed := previous == nil ||
+				previous.Status != current.Status ||
+				previous.Conclusion != current.Conclusion ||
+				previous.Attempt != current.Attempt ||
+				!reflect.DeepEqual(previous.Jobs, current.Jobs)
+
+			if changed {
+				current.Transitions = runTransitions(previous, current)
+				if !sendRunUpdate(ctx, updates, *current) {
+					return
+				}
+			}
+
+			if current.Status == "completed" {
+				return
+			}
+
+			previous = current
+			interval = nextWatchInterval(interval, changed, current.Status, u.githubRepository.RateLimit())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (u useCase) pollRun(ctx context.Context, input WatchRunInput) (*RunUpdate, error) {
+	workflowRun, err := u.githubRepository.GetWorkflowRun(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowJobs, err := u.githubRepository.ListJobsForRun(ctx, input.Repository, input.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	for _, workflowJob := range workflowJobs {
+		jobs = append(jobs, u.toJob(workflowJob))
+	}
+
+	return &RunUpdate{
+		Status:     workflowRun.Status,
+		Conclusion: workflowRun.Conclusion,
+		Attempt:    workflowRun.RunAttempt,
+		Jobs:       jobs,
+	}, nil
+}
+
+func sendRunUpdate(ctx context.Context, updates chan<- RunUpdate, update RunUpdate) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case updates <- update:
+		return true
+	}
+}
+
+// runTransitions lists the run and the jobs whose state is changed since the previous update
+func runTransitions(previous *RunUpdate, current *RunUpdate) []Transition {
+	if previous == nil {
+		return nil
+	}
+
+	var transitions []Transition
+	if from, to := runState(previous.Status, previous.Conclusion), runState(current.Status, current.Conclusion); from != to {
+		transitions = append(transitions, Transition{From: from, To: to})
+	}
+
+	previousJobs := make(map[int64]Job, len(previous.Jobs))
+	for _, job := range previous.Jobs {
+		previousJobs[job.ID] = job
+	}
+
+	for _, job := range current.Jobs {
+		var from string
+		if previousJob, ok := previousJobs[job.ID]; ok {
+			from = runState(previousJob.Status, previousJob.Conclusion)
+		}
+
+		if to := runState(job.Status, job.Conclusion); from != to {
+			transitions = append(transitions, Transition{JobName: job.Name, From: from, To: to})
+		}
+	}
+
+	return transitions
+}
+
+func runState(status string, conclusion string) string {
+	if status == "completed" && conclusion != "" {
+		return conclusion
+	}
+	return status
+}
+
+// nextWatchInterval polls again soon after a change, as more changes tend to follow, and backs off while nothing changes.
+// Queued runs can wait for a runner for long, and the polls slow down when the rate limit is about to run out.
+func nextWatchInterval(interval time.Duration, changed bool, status string, rateLimit *gr.RateLimit) time.Duration {
+	if rateLimit != nil && rateLimit.Limit > 0 && rateLimit.Remaining < rateLimit.Limit/10 {
+		return watchMaxInterval
+	}
+
+	switch status {
+	case "queued", "waiting", "pending", "requested":
+		return min(max(interval*3/2, watchQueuedInterval), watchMaxInterval)
+	}
+
+	if changed {
+		return watchMinInterval
+	}
+
+	return min(interval*3/2, watchMaxInterval)
+}
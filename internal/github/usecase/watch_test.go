@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gr "github.com/termkit/gama/internal/github/repository"
+)
+
+// watchRepository replays a workflow run through the given polls
+type watchRepository struct {
+	gr.Repository
+
+	polls []watchPoll
+	poll  int
+}
+
+type watchPoll struct {
+	run  gr.WorkflowRun
+	jobs []gr.WorkflowJob
+	err  error
+}
+
+func (r *watchRepository) WebURL() string { return "https://github.com" }
+
+func (r *watchRepository) RateLimit() *gr.RateLimit { return nil }
+
+func (r *watchRepository) GetWorkflowRun(ctx context.Context, repository string, runId int64) (*gr.WorkflowRun, error) {
+	poll := r.polls[min(r.poll, len(r.polls)-1)]
+	r.poll++
+	if poll.err != nil {
+		return nil, poll.err
+	}
+	return &poll.run, nil
+}
+
+func (r *watchRepository) ListJobsForRun(ctx context.Context, repository string, runId int64) ([]gr.WorkflowJob, error) {
+	return r.polls[min(r.poll-1, len(r.polls)-1)].jobs, nil
+}
+
+func shortenWatchIntervals(t *testing.T) {
+	minInterval, queuedInterval, maxInterval := watchMinInterval, watchQueuedInterval, watchMaxInterval
+	watchMinInterval, watchQueuedInterval, watchMaxInterval = time.Millisecond, time.Millisecond, time.Millisecond
+	t.Cleanup(func() {
+		watchMinInterval, watchQueuedInterval, watchMaxInterval = minInterval, queuedInterval, maxInterval
+	})
+}
+
+func TestUseCase_WatchRun(t *testing.T) {
+	shortenWatchIntervals(t)
+
+	repository := &watchRepository{polls: []watchPoll{
+		{run: gr.WorkflowRun{Status: "queued"}, jobs: []gr.WorkflowJob{{ID: 1, Name: "build", Status: "queued"}}},
+		{run: gr.WorkflowRun{Status: "queued"}, jobs: []gr.WorkflowJob{{ID: 1, Name: "build", Status: "queued"}}},
+		{err: &gr.APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}},
+		{run: gr.WorkflowRun{Status: "in_progress"}, jobs: []gr.WorkflowJob{{ID: 1, Name: "build", Status: "in_progress"}}},
+		{run: gr.WorkflowRun{Status: "completed", Conclusion: "failure"}, jobs: []gr.WorkflowJob{{ID: 1, Name: "build", Status: "completed", Conclusion: "failure"}}},
+	}}
+
+	output, err := New(repository).WatchRun(context.Background(), WatchRunInput{Repository: "owner/repo", WorkflowID: 1})
+	assert.NoError(t, err)
+
+	var updates []RunUpdate
+	for update := range output.Updates {
+		updates = append(updates, update)
+	}
+
+	// The unchanged poll is not sent, and the updates stop once the run is completed
+	assert.Len(t, updates, 4)
+	assert.Equal(t, "queued", updates[0].Status)
+	assert.Empty(t, updates[0].Transitions)
+	assert.Error(t, updates[1].Err)
+	assert.Equal(t, []Transition{
+		{From: "queued", To: "in_progress"},
+		{JobName: "build", From: "queued", To: "in_progress"},
+	}, updates[2].Transitions)
+	assert.Equal(t, []Transition{
+		{From: "in_progress", To: "failure"},
+		{JobName: "build", From: "in_progress", To: "failure"},
+	}, updates[3].Transitions)
+}
+
+func TestUseCase_WatchRun_ReRun(t *testing.T) {
+	shortenWatchIntervals(t)
+
+	repository := &watchRepository{polls: []watchPoll{
+		{run: gr.WorkflowRun{Status: "completed", Conclusion: "failure", RunAttempt: 1}},
+		{run: gr.WorkflowRun{Status: "completed", Conclusion: "failure", RunAttempt: 1}},
+		{run: gr.WorkflowRun{Status: "queued", RunAttempt: 2}},
+		{run: gr.WorkflowRun{Status: "completed", Conclusion: "success", RunAttempt: 2}},
+	}}
+
+	output, err := New(repository).WatchRun(context.Background(), WatchRunInput{Repository: "owner/repo", WorkflowID: 1, PreviousAttempt: 1})
+	assert.NoError(t, err)
+
+	var updates []RunUpdate
+	for update := range output.Updates {
+		updates = append(updates, update)
+	}
+
+	// The previous attempt is not sent, the watch goes on until the re-run completes
+	assert.Len(t, updates, 2)
+	assert.Equal(t, RunUpdate{Status: "queued", Attempt: 2}, updates[0])
+	assert.Equal(t, "success", updates[1].Conclusion)
+}
+
+func TestUseCase_WatchRun_ReRunNotStarted(t *testing.T) {
+	shortenWatchIntervals(t)
+
+	timeout := watchStartTimeout
+	watchStartTimeout = 10 * time.Millisecond
+	t.Cleanup(func() { watchStartTimeout = timeout })
+
+	repository := &watchRepository{polls: []watchPoll{
+		{run: gr.WorkflowRun{Status: "completed", Conclusion: "failure", RunAttempt: 1}},
+	}}
+
+	output, err := New(repository).WatchRun(context.Background(), WatchRunInput{Repository: "owner/repo", WorkflowID: 1, PreviousAttempt: 1})
+	assert.NoError(t, err)
+
+	// The watch does not poll the previous attempt forever
+	update, ok := <-output.Updates
+	assert.True(t, ok)
+	assert.True(t, IsRunNotStarted(update.Err))
+
+	_, ok = <-output.Updates
+	assert.False(t, ok)
+}
+
+func TestUseCase_WatchRun_NotFound(t *testing.T) {
+	shortenWatchIntervals(t)
+
+	repository := &watchRepository{polls: []watchPoll{
+		{err: &gr.APIError{StatusCode: http.StatusNotFound, Message: "Not Found"}},
+	}}
+
+	output, err := New(repository).WatchRun(context.Background(), WatchRunInput{Repository: "owner/repo", WorkflowID: 1})
+	assert.NoError(t, err)
+
+	update, ok := <-output.Updates
+	assert.True(t, ok)
+	assert.True(t, gr.IsNotFound(update.Err))
+
+	_, ok = <-output.Updates
+	assert.False(t, ok)
+}
+
+func TestNextWatchInterval(t *testing.T) {
+	assert.Equal(t, watchMinInterval, nextWatchInterval(20*time.Second, true, "in_progress", nil))
+	assert.Equal(t, 6*time.Second, nextWatchInterval(4*time.Second, false, "in_progress", nil))
+	assert.Equal(t, watchMaxInterval, nextWatchInterval(25*time.Second, false, "in_progress", nil))
+	assert.Equal(t, watchQueuedInterval, nextWatchInterval(watchMinInterval, true, "queued", nil))
+
+	lowRateLimit := &gr.RateLimit{Limit: 5000, Remaining: 100}
+	assert.Equal(t, watchMaxInterval, nextWatchInterval(watchMinInterval, true, "in_progress", lowRateLimit))
+}
@@ -1,10 +1,13 @@
 package error
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	gr "github.com/termkit/gama/internal/github/repository"
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	ts "github.com/termkit/gama/internal/terminal/style"
 )
@@ -98,10 +101,59 @@ func (m *ModelError) IsError() bool {
 
 func (m *ModelError) ViewError() string {
 	doc := strings.Builder{}
+
+	var apiError *gr.APIError
+	if errors.As(m.err, &apiError) {
+		doc.WriteString(fmt.Sprintf("Error [%d %s]: %s: %s",
+			apiError.StatusCode, http.StatusText(apiError.StatusCode), m.errorMessage, apiError.Error()))
+
+		var details []string
+		if hint := apiErrorHint(m.err); hint != "" {
+			details = append(details, hint)
+		}
+		if apiError.DocumentationURL != "" {
+			details = append(details, "Docs: "+apiError.DocumentationURL)
+		}
+		if apiError.RequestID != "" {
+			details = append(details, "Request ID: "+apiError.RequestID)
+		}
+		if len(details) > 0 {
+			doc.WriteString("\n" + strings.Join(details, " | "))
+		}
+
+		return doc.String()
+	}
+
 	doc.WriteString(fmt.Sprintf("Error [%v]: %s", m.err, m.errorMessage))
 	return doc.String()
 }
 
+// apiErrorHint returns what the user can do about the GitHub API error
+func apiErrorHint(err error) string {
+	switch {
+	case gr.IsTokenRefreshFailed(err):
+		return "The GitHub App could not get an installation token, check its app id, installation id and private key"
+	case gr.IsUnauthorized(err):
+		return "Your token is invalid or expired"
+	case missingScopes(err) != "":
+		return fmt.Sprintf("Your token is missing the %s scope, see the Info tab", missingScopes(err))
+	case gr.IsRateLimited(err):
+		return "API rate limit exceeded, try again later"
+	case gr.IsNotFound(err):
+		return "It does not exist or your token cannot access it"
+	}
+	return ""
+}
+
+// missingScopes returns the scopes the failed endpoint accepts if the token has none of them, like "repo or workflow"
+func missingScopes(err error) string {
+	var apiError *gr.APIError
+	if !errors.As(err, &apiError) {
+		return ""
+	}
+	return strings.Join(apiError.MissingScopes(), " or ")
+}
+
 func (m *ModelError) ViewMessage() string {
 	doc := strings.Builder{}
 	doc.WriteString(m.message)
@@ -0,0 +1,327 @@
+package ghartifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubArtifacts lists the artifacts of a workflow run to download or delete them
+type ModelGithubArtifacts struct {
+	// current handler's properties
+	isOpen               bool
+	workflowID           int64
+	workflowName         string
+	artifacts            []gu.Artifact
+	pendingDelete        int64 // artifact that is deleted if the delete key is pressed again
+	isDirectoryFocused   bool
+	syncArtifactsContext context.Context
+	cancelSyncArtifacts  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help               help.Model
+	Viewport           *viewport.Model
+	tableArtifacts     table.Model
+	textInputDirectory textinput.Model
+	modelError         hdlerror.ModelError
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubArtifacts(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubArtifacts {
+	tableArtifacts := table.New(
+		table.WithColumns(tableColumnsArtifacts),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableArtifacts.SetStyles(s)
+
+	// Download to the working directory by default
+	directory, err := os.Getwd()
+	if err != nil {
+		directory = "."
+	}
+
+	ti := textinput.New()
+	ti.Prompt = "Directory: "
+	ti.CharLimit = 256
+	ti.SetValue(directory)
+
+	return &ModelGithubArtifacts{
+		Help:                 help.New(),
+		Keys:                 keys,
+		githubUseCase:        githubUseCase,
+		SelectedRepository:   selectedRepository,
+		modelError:           hdlerror.SetupModelError(),
+		tableArtifacts:       tableArtifacts,
+		textInputDirectory:   ti,
+		syncArtifactsContext: context.Background(),
+		cancelSyncArtifacts:  func() {},
+	}
+}
+
+func (m *ModelGithubArtifacts) Init() tea.Cmd {
+	return nil
+}
+
+// Open lists the artifacts of the given workflow run
+func (m *ModelGithubArtifacts) Open(workflowID int64, workflowName string) {
+	m.cancelSyncArtifacts() // cancel previous sync
+	m.syncArtifactsContext, m.cancelSyncArtifacts = context.WithCancel(context.Background())
+
+	m.isOpen = true
+	m.workflowID = workflowID
+	m.workflowName = workflowName
+	m.pendingDelete = 0
+	m.isDirectoryFocused = false
+	m.textInputDirectory.Blur()
+	m.tableArtifacts.Focus()
+
+	go m.syncArtifacts(m.syncArtifactsContext)
+}
+
+func (m *ModelGithubArtifacts) Close() {
+	m.cancelSyncArtifacts()
+	m.isOpen = false
+}
+
+func (m *ModelGithubArtifacts) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m *ModelGithubArtifacts) syncArtifacts(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(
+		fmt.Sprintf("[%s] Fetching artifacts of run #%d...", m.SelectedRepository.RepositoryName, m.workflowID))
+
+	// delete all rows
+	m.tableArtifacts.SetRows([]table.Row{})
+	m.artifacts = nil
+
+	output, err := m.githubUseCase.ListArtifacts(ctx, gu.ListArtifactsInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		WorkflowID: m.workflowID,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Artifacts cannot be listed")
+		return
+	}
+
+	if len(output.Artifacts) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] Run #%d has no artifacts.", m.SelectedRepository.RepositoryName, m.workflowID))
+		return
+	}
+
+	var tableRowsArtifacts []table.Row
+	for _, artifact := range output.Artifacts {
+		tableRowsArtifacts = append(tableRowsArtifacts, table.Row{
+			artifact.Name,
+			hdltypes.FormatSize(artifact.SizeInBytes),
+			formatExpiry(artifact.ExpiresAt, artifact.Expired, time.Now()),
+		})
+	}
+
+	m.artifacts = output.Artifacts
+	m.tableArtifacts.SetRows(tableRowsArtifacts)
+	m.tableArtifacts.SetCursor(0)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Artifacts of run #%d fetched.", m.SelectedRepository.RepositoryName, m.workflowID))
+}
+
+func (m *ModelGithubArtifacts) selectedArtifact() *gu.Artifact {
+	cursor := m.tableArtifacts.Cursor()
+	if cursor < 0 || cursor >= len(m.artifacts) {
+		return nil
+	}
+	return &m.artifacts[cursor]
+}
+
+func (m *ModelGithubArtifacts) downloadArtifact(artifact gu.Artifact, extract bool) {
+	if artifact.Expired {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("Artifact %s is expired.", artifact.Name))
+		return
+	}
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Downloading %s (%s)...", artifact.Name, hdltypes.FormatSize(artifact.SizeInBytes)))
+
+	output, err := m.githubUseCase.DownloadArtifact(m.syncArtifactsContext, gu.DownloadArtifactInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		ArtifactID: artifact.ID,
+		Name:       artifact.Name,
+		Directory:  m.textInputDirectory.Value(),
+		Extract:    extract,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("Artifact %s cannot be downloaded", artifact.Name))
+		return
+	}
+
+	m.modelError.SetSuccessMessage(fmt.Sprintf("Artifact %s is saved to %s", artifact.Name, output.Path))
+}
+
+func (m *ModelGithubArtifacts) deleteArtifact(artifact gu.Artifact) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Deleting %s...", artifact.Name))
+
+	_, err := m.githubUseCase.DeleteArtifact(m.syncArtifactsContext, gu.DeleteArtifactInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		ArtifactID: artifact.ID,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("Artifact %s cannot be deleted", artifact.Name))
+		return
+	}
+
+	m.syncArtifacts(m.syncArtifactsContext)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("Artifact %s is deleted.", artifact.Name))
+}
+
+func (m *ModelGithubArtifacts) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	if m.isDirectoryFocused {
+		switch keyMsg.String() {
+		case "tab", "enter", "esc":
+			m.isDirectoryFocused = false
+			m.textInputDirectory.Blur()
+			m.tableArtifacts.Focus()
+			return m, nil
+		}
+
+		m.textInputDirectory, cmd = m.textInputDirectory.Update(msg)
+		return m, cmd
+	}
+
+	// Deleting needs the key to be pressed twice on the same artifact
+	var pendingDelete = m.pendingDelete
+	m.pendingDelete = 0
+
+	switch keyMsg.String() {
+	case "esc":
+		m.Close()
+		return m, nil
+	case "tab":
+		m.isDirectoryFocused = true
+		m.tableArtifacts.Blur()
+		return m, m.textInputDirectory.Focus()
+	case "r", "R":
+		go m.syncArtifacts(m.syncArtifactsContext)
+		return m, nil
+	case "d", "e":
+		if artifact := m.selectedArtifact(); artifact != nil {
+			go m.downloadArtifact(*artifact, keyMsg.String() == "e")
+		}
+		return m, nil
+	case "x":
+		if artifact := m.selectedArtifact(); artifact != nil {
+			if pendingDelete == artifact.ID {
+				go m.deleteArtifact(*artifact)
+			} else {
+				m.pendingDelete = artifact.ID
+				m.modelError.SetDefaultMessage(fmt.Sprintf("Press x again to delete %s.", artifact.Name))
+			}
+		}
+		return m, nil
+	}
+
+	m.tableArtifacts, cmd = m.tableArtifacts.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubArtifacts) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsArtifacts {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsArtifacts
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[0].Width += widthDiff - 13
+		m.tableArtifacts.SetColumns(newTableColumns)
+		m.tableArtifacts.SetHeight(termHeight - 21)
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Artifacts of run #%d · %s", m.workflowID, m.workflowName))
+
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(termWidth - 13)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.tableArtifacts.View())),
+		inputStyle.Render(m.textInputDirectory.View()))
+}
+
+func (m *ModelGithubArtifacts) ViewStatus() string {
+	return m.modelError.View()
+}
+
+// formatExpiry formats the time left until the artifact expires, like "in 3 days"
+func formatExpiry(expiresAt time.Time, expired bool, now time.Time) string {
+	left := expiresAt.Sub(now)
+	switch {
+	case expired || left <= 0:
+		return "expired"
+	case left < time.Hour:
+		return "in <1 hour"
+	case left < 24*time.Hour:
+		return fmt.Sprintf("in %d hours", int(left.Hours()))
+	default:
+		return fmt.Sprintf("in %d days", int(left.Hours()/24))
+	}
+}
@@ -0,0 +1,60 @@
+package ghartifacts
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Download  teakey.Binding
+	Extract   teakey.Binding
+	Delete    teakey.Binding
+	Directory teakey.Binding
+	Refresh   teakey.Binding
+	Back      teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Download, k.Extract, k.Delete, k.Directory, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Download},
+		{k.Extract},
+		{k.Delete},
+		{k.Directory},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	Download: teakey.NewBinding(
+		teakey.WithKeys("d"),
+		teakey.WithHelp("d", "download"),
+	),
+	Extract: teakey.NewBinding(
+		teakey.WithKeys("e"),
+		teakey.WithHelp("e", "download and extract"),
+	),
+	Delete: teakey.NewBinding(
+		teakey.WithKeys("x"),
+		teakey.WithHelp("x x", "delete"),
+	),
+	Directory: teakey.NewBinding(
+		teakey.WithKeys("tab"),
+		teakey.WithHelp("tab", "edit directory"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh artifacts"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+func (m *ModelGithubArtifacts) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
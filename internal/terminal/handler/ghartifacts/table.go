@@ -0,0 +1,11 @@
+package ghartifacts
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsArtifacts = []table.Column{
+	{Title: "Artifact", Width: 40},
+	{Title: "Size", Width: 10},
+	{Title: "Expires", Width: 16},
+}
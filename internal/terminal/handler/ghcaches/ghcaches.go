@@ -0,0 +1,385 @@
+package ghcaches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubCaches lists the actions caches of the selected repository, to delete the stale ones
+type ModelGithubCaches struct {
+	// current handler's properties
+	lastRepository    string
+	caches            []gu.Cache
+	selected          map[int64]bool // ids of the caches selected to be deleted
+	sortBy            cacheSort
+	pendingDelete     bool // the selected caches are deleted if the delete key is pressed again
+	usageInBytes      int64
+	limitInBytes      int64
+	syncCachesContext context.Context
+	cancelSyncCaches  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help        help.Model
+	Viewport    *viewport.Model
+	tableCaches table.Model
+	modelError  hdlerror.ModelError
+}
+
+// cacheSort is the order of the caches in the table
+type cacheSort int
+
+const (
+	sortBySize         cacheSort = iota // largest first
+	sortByLastAccessed                  // least recently used first
+	sortByCreated                       // oldest first
+)
+
+func (s cacheSort) String() string {
+	switch s {
+	case sortByLastAccessed:
+		return "last accessed"
+	case sortByCreated:
+		return "created"
+	default:
+		return "size"
+	}
+}
+
+// usageBarWidth is the width of the bar of the cache usage
+const usageBarWidth = 30
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubCaches(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubCaches {
+	tableCaches := table.New(
+		table.WithColumns(tableColumnsCaches),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableCaches.SetStyles(s)
+
+	return &ModelGithubCaches{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		tableCaches:        tableCaches,
+		selected:           make(map[int64]bool),
+		syncCachesContext:  context.Background(),
+		cancelSyncCaches:   func() {},
+	}
+}
+
+// Close stops the sync of the caches, the model is not used afterward
+func (m *ModelGithubCaches) Close() {
+	m.cancelSyncCaches()
+}
+
+func (m *ModelGithubCaches) Init() tea.Cmd {
+	return nil
+}
+
+func (m *ModelGithubCaches) syncCaches(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching caches...", m.SelectedRepository.RepositoryName))
+
+	// delete all rows
+	m.tableCaches.SetRows([]table.Row{})
+	m.caches = nil
+	m.selected = make(map[int64]bool)
+	m.pendingDelete = false
+
+	output, err := m.githubUseCase.ListCaches(ctx, gu.ListCachesInput{
+		Repository: m.SelectedRepository.RepositoryName,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Caches cannot be listed")
+		return
+	}
+
+	m.usageInBytes = output.UsageInBytes
+	m.limitInBytes = output.LimitInBytes
+	m.caches = output.Caches
+	m.sortCaches()
+	m.tableCaches.SetCursor(0)
+
+	if len(m.caches) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] No caches found.", m.SelectedRepository.RepositoryName))
+		return
+	}
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Caches fetched.", m.SelectedRepository.RepositoryName))
+}
+
+// sortCaches sorts the caches by the current order and renders the rows of the table
+func (m *ModelGithubCaches) sortCaches() {
+	sort.SliceStable(m.caches, func(i, j int) bool {
+		switch m.sortBy {
+		case sortByLastAccessed:
+			return m.caches[i].LastAccessedAt.Before(m.caches[j].LastAccessedAt)
+		case sortByCreated:
+			return m.caches[i].CreatedAt.Before(m.caches[j].CreatedAt)
+		default:
+			return m.caches[i].SizeInBytes > m.caches[j].SizeInBytes
+		}
+	})
+
+	m.renderRows()
+}
+
+func (m *ModelGithubCaches) renderRows() {
+	var now = time.Now()
+	var tableRowsCaches []table.Row
+	for _, cache := range m.caches {
+		var mark = " "
+		if m.selected[cache.ID] {
+			mark = "✓"
+		}
+
+		tableRowsCaches = append(tableRowsCaches, table.Row{
+			mark,
+			cache.Key,
+			strings.TrimPrefix(cache.Ref, "refs/heads/"),
+			hdltypes.FormatSize(cache.SizeInBytes),
+			formatAge(cache.LastAccessedAt, now),
+			formatAge(cache.CreatedAt, now),
+		})
+	}
+
+	m.tableCaches.SetRows(tableRowsCaches)
+}
+
+// cachesToDelete returns the selected caches, or the cache under the cursor if none is selected
+func (m *ModelGithubCaches) cachesToDelete() []gu.Cache {
+	var caches []gu.Cache
+	for _, cache := range m.caches {
+		if m.selected[cache.ID] {
+			caches = append(caches, cache)
+		}
+	}
+
+	cursor := m.tableCaches.Cursor()
+	if len(caches) == 0 && cursor >= 0 && cursor < len(m.caches) {
+		caches = append(caches, m.caches[cursor])
+	}
+	return caches
+}
+
+func (m *ModelGithubCaches) deleteCaches(caches []gu.Cache) {
+	var cacheIDs []int64
+	var size int64
+	for _, cache := range caches {
+		cacheIDs = append(cacheIDs, cache.ID)
+		size += cache.SizeInBytes
+	}
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Deleting %s...", describeCaches(caches)))
+
+	_, err := m.githubUseCase.DeleteCaches(m.syncCachesContext, gu.DeleteCachesInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		CacheIDs:   cacheIDs,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.syncCaches(m.syncCachesContext) // some of the caches may be deleted
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Caches cannot be deleted")
+		return
+	}
+
+	m.syncCaches(m.syncCachesContext)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("Deleted %s, %s is freed.", describeCaches(caches), hdltypes.FormatSize(size)))
+}
+
+// describeCaches names a single cache by its key, and counts the others
+func describeCaches(caches []gu.Cache) string {
+	if len(caches) == 1 {
+		return caches[0].Key
+	}
+	return fmt.Sprintf("%d caches", len(caches))
+}
+
+func (m *ModelGithubCaches) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.lastRepository != m.SelectedRepository.RepositoryName {
+		m.cancelSyncCaches() // cancel previous sync
+		m.syncCachesContext, m.cancelSyncCaches = context.WithCancel(context.Background())
+
+		m.lastRepository = m.SelectedRepository.RepositoryName
+
+		go m.syncCaches(m.syncCachesContext)
+	}
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	// Deleting needs the key to be pressed twice
+	var pendingDelete = m.pendingDelete
+	m.pendingDelete = false
+
+	switch keyMsg.String() {
+	case "r", "R":
+		go m.syncCaches(m.syncCachesContext)
+		return m, nil
+	case " ":
+		cursor := m.tableCaches.Cursor()
+		if cursor >= 0 && cursor < len(m.caches) {
+			id := m.caches[cursor].ID
+			m.selected[id] = !m.selected[id]
+			m.renderRows()
+		}
+		return m, nil
+	case "a":
+		var selectAll = m.selectedCount() < len(m.caches)
+		for _, cache := range m.caches {
+			m.selected[cache.ID] = selectAll
+		}
+		m.renderRows()
+		return m, nil
+	case "s":
+		m.sortBy = (m.sortBy + 1) % 3
+		m.sortCaches()
+		m.modelError.SetDefaultMessage(fmt.Sprintf("Caches are sorted by %s.", m.sortBy))
+		return m, nil
+	case "x":
+		caches := m.cachesToDelete()
+		if len(caches) == 0 {
+			return m, nil
+		}
+
+		if pendingDelete {
+			go m.deleteCaches(caches)
+			return m, nil
+		}
+
+		m.pendingDelete = true
+		m.modelError.SetDefaultMessage(fmt.Sprintf("Press x again to delete %s.", describeCaches(caches)))
+		return m, nil
+	}
+
+	m.tableCaches, cmd = m.tableCaches.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubCaches) selectedCount() int {
+	var count int
+	for _, selected := range m.selected {
+		if selected {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *ModelGithubCaches) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsCaches {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsCaches
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[1].Width += widthDiff - 19
+		m.tableCaches.SetColumns(newTableColumns)
+		m.tableCaches.SetHeight(termHeight - 14)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		m.viewUsage(usageBarWidth),
+		baseStyle.Render(m.tableCaches.View()))
+}
+
+// viewUsage renders the total size of the caches against the limit, like "Cache usage 3.2 GB of 10 GB [████░░░░] 32%"
+func (m *ModelGithubCaches) viewUsage(barWidth int) string {
+	if m.limitInBytes == 0 {
+		return " Cache usage"
+	}
+
+	var ratio = min(float64(m.usageInBytes)/float64(m.limitInBytes), 1)
+	var filled = int(ratio * float64(barWidth))
+
+	var color = lipgloss.Color("10")
+	switch {
+	case ratio >= 0.9:
+		color = lipgloss.Color("9")
+	case ratio >= 0.7:
+		color = lipgloss.Color("11")
+	}
+
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(strings.Repeat("░", max(0, barWidth-filled)))
+
+	return fmt.Sprintf(" Cache usage %s of %s %s %d%%  sorted by %s",
+		hdltypes.FormatSize(m.usageInBytes), hdltypes.FormatSize(m.limitInBytes), bar, int(ratio*100), m.sortBy)
+}
+
+func (m *ModelGithubCaches) ViewStatus() string {
+	return m.modelError.View()
+}
+
+// formatAge formats the time passed since t, like "3 days ago"
+func formatAge(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%d min ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%d hours ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%d days ago", int(age.Hours()/24))
+	}
+}
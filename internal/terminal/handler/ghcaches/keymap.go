@@ -0,0 +1,60 @@
+package ghcaches
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	TabSwitch teakey.Binding
+	Select    teakey.Binding
+	SelectAll teakey.Binding
+	Sort      teakey.Binding
+	Delete    teakey.Binding
+	Refresh   teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.TabSwitch, k.Select, k.SelectAll, k.Sort, k.Delete, k.Refresh}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.TabSwitch},
+		{k.Select},
+		{k.SelectAll},
+		{k.Sort},
+		{k.Delete},
+		{k.Refresh},
+	}
+}
+
+var keys = keyMap{
+	TabSwitch: teakey.NewBinding(
+		teakey.WithKeys("shift+left", "shift+right"),
+		teakey.WithHelp("shift + (← | →)", "switch tab"),
+	),
+	Select: teakey.NewBinding(
+		teakey.WithKeys(" "),
+		teakey.WithHelp("space", "select"),
+	),
+	SelectAll: teakey.NewBinding(
+		teakey.WithKeys("a"),
+		teakey.WithHelp("a", "select all"),
+	),
+	Sort: teakey.NewBinding(
+		teakey.WithKeys("s"),
+		teakey.WithHelp("s", "change sort"),
+	),
+	Delete: teakey.NewBinding(
+		teakey.WithKeys("x"),
+		teakey.WithHelp("x x", "delete"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh"),
+	),
+}
+
+func (m *ModelGithubCaches) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
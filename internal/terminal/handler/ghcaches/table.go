@@ -0,0 +1,14 @@
+package ghcaches
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsCaches = []table.Column{
+	{Title: " ", Width: 1},
+	{Title: "Key", Width: 36},
+	{Title: "Ref", Width: 20},
+	{Title: "Size", Width: 9},
+	{Title: "Last accessed", Width: 14},
+	{Title: "Created", Width: 14},
+}
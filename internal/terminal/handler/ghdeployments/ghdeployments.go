@@ -0,0 +1,314 @@
+package ghdeployments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubDeployments lists the environments that a workflow run waits for, to approve or reject them
+type ModelGithubDeployments struct {
+	// current handler's properties
+	isOpen                 bool
+	workflowID             int64
+	workflowName           string
+	deployments            []gu.PendingDeployment
+	pendingReview          string // key that reviews the selected environment if it is pressed again
+	isCommentFocused       bool
+	syncDeploymentsContext context.Context
+	cancelSyncDeployments  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help             help.Model
+	Viewport         *viewport.Model
+	tableDeployments table.Model
+	textInputComment textinput.Model
+	modelError       hdlerror.ModelError
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubDeployments(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubDeployments {
+	tableDeployments := table.New(
+		table.WithColumns(tableColumnsDeployments),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableDeployments.SetStyles(s)
+
+	ti := textinput.New()
+	ti.Prompt = "Comment: "
+	ti.Placeholder = "Optional comment of the review"
+	ti.CharLimit = 256
+
+	return &ModelGithubDeployments{
+		Help:                   help.New(),
+		Keys:                   keys,
+		githubUseCase:          githubUseCase,
+		SelectedRepository:     selectedRepository,
+		modelError:             hdlerror.SetupModelError(),
+		tableDeployments:       tableDeployments,
+		textInputComment:       ti,
+		syncDeploymentsContext: context.Background(),
+		cancelSyncDeployments:  func() {},
+	}
+}
+
+func (m *ModelGithubDeployments) Init() tea.Cmd {
+	return nil
+}
+
+// Open lists the environments that the given workflow run waits for
+func (m *ModelGithubDeployments) Open(workflowID int64, workflowName string) {
+	m.cancelSyncDeployments() // cancel previous sync
+	m.syncDeploymentsContext, m.cancelSyncDeployments = context.WithCancel(context.Background())
+
+	m.isOpen = true
+	m.workflowID = workflowID
+	m.workflowName = workflowName
+	m.pendingReview = ""
+	m.isCommentFocused = false
+	m.textInputComment.Reset()
+	m.textInputComment.Blur()
+	m.tableDeployments.Focus()
+
+	go m.syncDeployments(m.syncDeploymentsContext)
+}
+
+func (m *ModelGithubDeployments) Close() {
+	m.cancelSyncDeployments()
+	m.isOpen = false
+}
+
+func (m *ModelGithubDeployments) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m *ModelGithubDeployments) syncDeployments(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(
+		fmt.Sprintf("[%s] Fetching pending deployments of run #%d...", m.SelectedRepository.RepositoryName, m.workflowID))
+
+	// delete all rows
+	m.tableDeployments.SetRows([]table.Row{})
+	m.deployments = nil
+
+	output, err := m.githubUseCase.GetPendingDeployments(ctx, gu.GetPendingDeploymentsInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		WorkflowID: m.workflowID,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Pending deployments cannot be listed")
+		return
+	}
+
+	if len(output.Deployments) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] Run #%d is not waiting for any environment.", m.SelectedRepository.RepositoryName, m.workflowID))
+		return
+	}
+
+	var tableRowsDeployments []table.Row
+	for _, deployment := range output.Deployments {
+		var canApprove = "no"
+		if deployment.CanApprove {
+			canApprove = "yes"
+		}
+
+		var waitTimer = "-"
+		if deployment.WaitTimer > 0 {
+			waitTimer = fmt.Sprintf("%d min", deployment.WaitTimer)
+		}
+
+		tableRowsDeployments = append(tableRowsDeployments, table.Row{
+			deployment.EnvironmentName,
+			strings.Join(deployment.Reviewers, ", "),
+			canApprove,
+			waitTimer,
+		})
+	}
+
+	m.deployments = output.Deployments
+	m.tableDeployments.SetRows(tableRowsDeployments)
+	m.tableDeployments.SetCursor(0)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Pending deployments of run #%d fetched.", m.SelectedRepository.RepositoryName, m.workflowID))
+}
+
+func (m *ModelGithubDeployments) selectedDeployment() *gu.PendingDeployment {
+	cursor := m.tableDeployments.Cursor()
+	if cursor < 0 || cursor >= len(m.deployments) {
+		return nil
+	}
+	return &m.deployments[cursor]
+}
+
+func (m *ModelGithubDeployments) reviewDeployment(deployment gu.PendingDeployment, approve bool) {
+	var action = "Rejecting"
+	if approve {
+		action = "Approving"
+	}
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("%s %s...", action, deployment.EnvironmentName))
+
+	_, err := m.githubUseCase.ReviewPendingDeployments(m.syncDeploymentsContext, gu.ReviewPendingDeploymentsInput{
+		Repository:     m.SelectedRepository.RepositoryName,
+		WorkflowID:     m.workflowID,
+		EnvironmentIDs: []int64{deployment.EnvironmentID},
+		Approve:        approve,
+		Comment:        m.textInputComment.Value(),
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("Deployment to %s cannot be reviewed", deployment.EnvironmentName))
+		return
+	}
+
+	m.textInputComment.Reset()
+	m.syncDeployments(m.syncDeploymentsContext)
+
+	if approve {
+		m.modelError.SetSuccessMessage(fmt.Sprintf("Deployment to %s is approved.", deployment.EnvironmentName))
+	} else {
+		m.modelError.SetSuccessMessage(fmt.Sprintf("Deployment to %s is rejected.", deployment.EnvironmentName))
+	}
+}
+
+func (m *ModelGithubDeployments) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	if m.isCommentFocused {
+		switch keyMsg.String() {
+		case "tab", "enter", "esc":
+			m.isCommentFocused = false
+			m.textInputComment.Blur()
+			m.tableDeployments.Focus()
+			return m, nil
+		}
+
+		m.textInputComment, cmd = m.textInputComment.Update(msg)
+		return m, cmd
+	}
+
+	// Reviewing needs the key to be pressed twice on the same environment
+	var pendingReview = m.pendingReview
+	m.pendingReview = ""
+
+	switch key := keyMsg.String(); key {
+	case "esc":
+		m.Close()
+		return m, nil
+	case "tab":
+		m.isCommentFocused = true
+		m.tableDeployments.Blur()
+		return m, m.textInputComment.Focus()
+	case "r", "R":
+		go m.syncDeployments(m.syncDeploymentsContext)
+		return m, nil
+	case "a", "x":
+		deployment := m.selectedDeployment()
+		if deployment == nil {
+			return m, nil
+		}
+
+		var approve = key == "a"
+		if !deployment.CanApprove {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("You are not a reviewer of %s.", deployment.EnvironmentName))
+			return m, nil
+		}
+
+		reviewKey := fmt.Sprintf("%s/%d", key, deployment.EnvironmentID)
+		if pendingReview == reviewKey {
+			go m.reviewDeployment(*deployment, approve)
+			return m, nil
+		}
+
+		m.pendingReview = reviewKey
+		if approve {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("Press a again to approve the deployment to %s.", deployment.EnvironmentName))
+		} else {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("Press x again to reject the deployment to %s.", deployment.EnvironmentName))
+		}
+		return m, nil
+	}
+
+	m.tableDeployments, cmd = m.tableDeployments.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubDeployments) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsDeployments {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsDeployments
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[1].Width += widthDiff - 15
+		m.tableDeployments.SetColumns(newTableColumns)
+		m.tableDeployments.SetHeight(termHeight - 21)
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Pending deployments of run #%d · %s", m.workflowID, m.workflowName))
+
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(termWidth - 13)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.tableDeployments.View())),
+		inputStyle.Render(m.textInputComment.View()))
+}
+
+func (m *ModelGithubDeployments) ViewStatus() string {
+	return m.modelError.View()
+}
@@ -0,0 +1,54 @@
+package ghdeployments
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Approve teakey.Binding
+	Reject  teakey.Binding
+	Comment teakey.Binding
+	Refresh teakey.Binding
+	Back    teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Approve, k.Reject, k.Comment, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Approve},
+		{k.Reject},
+		{k.Comment},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	Approve: teakey.NewBinding(
+		teakey.WithKeys("a"),
+		teakey.WithHelp("a a", "approve"),
+	),
+	Reject: teakey.NewBinding(
+		teakey.WithKeys("x"),
+		teakey.WithHelp("x x", "reject"),
+	),
+	Comment: teakey.NewBinding(
+		teakey.WithKeys("tab"),
+		teakey.WithHelp("tab", "edit comment"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh deployments"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+func (m *ModelGithubDeployments) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
@@ -0,0 +1,12 @@
+package ghdeployments
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsDeployments = []table.Column{
+	{Title: "Environment", Width: 24},
+	{Title: "Reviewers", Width: 32},
+	{Title: "Can approve", Width: 11},
+	{Title: "Wait timer", Width: 10},
+}
@@ -0,0 +1,187 @@
+package ghrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+)
+
+// openBranchPicker opens the branch picker for the selected repository and fetches its branches
+func (m *ModelGithubRepository) openBranchPicker() {
+	if !m.tableReady || m.SelectedRepository.RepositoryName == "" {
+		return
+	}
+
+	m.cancelSyncBranches() // cancel previous sync
+	m.syncBranchesContext, m.cancelSyncBranches = context.WithCancel(context.Background())
+
+	m.branchPickerRepository = m.SelectedRepository.RepositoryName
+	m.branchPickerDefault = m.SelectedRepository.BranchName
+	m.textInputBranch.SetValue("")
+	m.textInputBranch.Focus()
+	m.isBranchPickerOpen = true
+
+	m.syncBranches(m.syncBranchesContext)
+}
+
+func (m *ModelGithubRepository) closeBranchPicker() {
+	m.cancelSyncBranches()
+	m.isBranchPickerOpen = false
+	m.branches = nil
+	m.textInputBranch.Blur()
+	m.tableBranches.SetRows([]table.Row{})
+}
+
+func (m *ModelGithubRepository) syncBranches(ctx context.Context) {
+	m.modelError.ResetError() // reset previous errors
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching branches...", m.branchPickerRepository))
+
+	// delete all rows
+	m.tableBranches.SetRows([]table.Row{})
+	m.branches = nil
+
+	branches, err := m.githubUseCase.ListBranches(ctx, gu.ListBranchesInput{
+		Repository: m.branchPickerRepository,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Branches cannot be listed")
+		return
+	}
+
+	// Keep the default branch at the top of the list
+	var sortedBranches []gu.GithubBranch
+	for _, branch := range branches.Branches {
+		if branch.Name == m.branchPickerDefault {
+			sortedBranches = append([]gu.GithubBranch{branch}, sortedBranches...)
+		} else {
+			sortedBranches = append(sortedBranches, branch)
+		}
+	}
+
+	m.branches = sortedBranches
+	m.filterBranches()
+
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %d branches fetched", m.branchPickerRepository, len(m.branches)))
+}
+
+// filterBranches fills the branch table with the branches that match the search input
+func (m *ModelGithubRepository) filterBranches() {
+	filter := strings.ToLower(strings.TrimSpace(m.textInputBranch.Value()))
+
+	var tableRowsBranches []table.Row
+	for _, branch := range m.branches {
+		if filter != "" && !strings.Contains(strings.ToLower(branch.Name), filter) {
+			continue
+		}
+
+		var protected string
+		if branch.Protected {
+			protected = "yes"
+		}
+
+		var commitSHA = branch.CommitSHA
+		if len(commitSHA) > 7 {
+			commitSHA = commitSHA[:7]
+		}
+
+		tableRowsBranches = append(tableRowsBranches, table.Row{branch.Name, protected, commitSHA})
+	}
+
+	m.tableBranches.SetRows(tableRowsBranches)
+	m.tableBranches.SetCursor(0)
+}
+
+// selectBranch makes the highlighted branch the working branch of the repository
+func (m *ModelGithubRepository) selectBranch() {
+	selectedRow := m.tableBranches.SelectedRow()
+	if len(selectedRow) == 0 || selectedRow[0] == "" {
+		return
+	}
+
+	var branchName = selectedRow[0]
+	m.repositoriesMutex.Lock()
+	m.selectedBranches[m.branchPickerRepository] = branchName
+	m.repositoriesMutex.Unlock()
+
+	// Reflect the selected branch in the repository table
+	rows := m.tableGithubRepository.Rows()
+	for i, row := range rows {
+		if row[0] == m.branchPickerRepository {
+			rows[i][1] = branchName
+		}
+	}
+	m.tableGithubRepository.SetRows(rows)
+
+	m.SelectedRepository.BranchName = branchName
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s] Branch selected", m.branchPickerRepository, branchName))
+
+	m.closeBranchPicker()
+}
+
+func (m *ModelGithubRepository) updateBranchPicker(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.closeBranchPicker()
+			m.modelError.SetDefaultMessage("Branch selection cancelled")
+			return nil
+		case "enter":
+			m.selectBranch()
+			return nil
+		case "up":
+			m.tableBranches.MoveUp(1)
+			return nil
+		case "down":
+			m.tableBranches.MoveDown(1)
+			return nil
+		}
+	}
+
+	var previousFilter = m.textInputBranch.Value()
+	m.textInputBranch, cmd = m.textInputBranch.Update(msg)
+	if m.textInputBranch.Value() != previousFilter {
+		m.filterBranches()
+	}
+
+	return cmd
+}
+
+func (m *ModelGithubRepository) viewBranchPicker() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsBranches {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsBranches
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[0].Width += widthDiff - 15
+		m.tableBranches.SetColumns(newTableColumns)
+		m.tableBranches.SetHeight(termHeight - 20)
+	}
+
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(termWidth - 13)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		inputStyle.Render(m.textInputBranch.View()),
+		baseStyle.Render(m.tableBranches.View()))
+}
@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	"github.com/termkit/gama/internal/terminal/handler/ghrunners"
+	"github.com/termkit/gama/internal/terminal/handler/ghsecrets"
+	"github.com/termkit/gama/internal/terminal/handler/ghusage"
 	"github.com/termkit/gama/internal/terminal/handler/taboptions"
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	"github.com/termkit/gama/pkg/browser"
@@ -24,6 +29,18 @@ type ModelGithubRepository struct {
 	syncRepositoriesContext context.Context
 	cancelSyncRepositories  context.CancelFunc
 	tableReady              bool
+	repositorySources       gu.RepositorySources
+
+	// branch picker properties
+	syncBranchesContext    context.Context
+	cancelSyncBranches     context.CancelFunc
+	isBranchPickerOpen     bool
+	branchPickerRepository string
+	branchPickerDefault    string
+	branches               []gu.GithubBranch
+	selectedBranches       map[string]string // repository name -> selected branch
+	repositoriesMutex      sync.RWMutex      // guards the maps of the repositories, syncRepositories runs in a goroutine
+	repositoryURLs         map[string]string // repository name -> repository page
 
 	// shared properties
 	SelectedRepository *hdltypes.SelectedRepository
@@ -38,17 +55,23 @@ type ModelGithubRepository struct {
 	Help                  help.Model
 	Viewport              *viewport.Model
 	tableGithubRepository table.Model
+	tableBranches         table.Model
+	textInputBranch       textinput.Model
 	modelError            hdlerror.ModelError
 
 	modelTabOptions       tea.Model
 	actualModelTabOptions *taboptions.Options
+
+	modelUsage   *ghusage.ModelGithubUsage
+	modelRunners *ghrunners.ModelGithubRunners
+	modelSecrets *ghsecrets.ModelGithubSecrets
 }
 
 var baseStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
 	BorderForeground(lipgloss.Color("240"))
 
-func SetupModelGithubRepository(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubRepository {
+func SetupModelGithubRepository(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository, repositorySources gu.RepositorySources) *ModelGithubRepository {
 	var tableRowsGithubRepository []table.Row
 
 	tableGithubRepository := table.New(
@@ -70,6 +93,17 @@ func SetupModelGithubRepository(githubUseCase gu.UseCase, selectedRepository *hd
 		Bold(false)
 	tableGithubRepository.SetStyles(s)
 
+	tableBranches := table.New(
+		table.WithColumns(tableColumnsBranches),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	tableBranches.SetStyles(s)
+
+	ti := textinput.New()
+	ti.Placeholder = "Search branch"
+	ti.CharLimit = 128
+
 	// setup models
 	modelError := hdlerror.SetupModelError()
 	tabOptions := taboptions.NewOptions()
@@ -85,16 +119,39 @@ func SetupModelGithubRepository(githubUseCase gu.UseCase, selectedRepository *hd
 		actualModelTabOptions:   tabOptions,
 		syncRepositoriesContext: context.Background(),
 		cancelSyncRepositories:  func() {},
+		syncBranchesContext:     context.Background(),
+		cancelSyncBranches:      func() {},
+		repositorySources:       repositorySources,
+		selectedBranches:        make(map[string]string),
+		repositoryURLs:          make(map[string]string),
+		tableBranches:           tableBranches,
+		textInputBranch:         ti,
+		modelUsage:              ghusage.SetupModelGithubUsage(githubUseCase, selectedRepository),
+		modelRunners:            ghrunners.SetupModelGithubRunners(githubUseCase, selectedRepository),
+		modelSecrets:            ghsecrets.SetupModelGithubSecrets(githubUseCase, selectedRepository),
 	}
 }
 
+// Close stops the syncs of the repositories and of the branches, and closes the open windows
+func (m *ModelGithubRepository) Close() {
+	m.cancelSyncRepositories()
+	m.cancelSyncBranches()
+	m.modelUsage.Close()
+	m.modelRunners.Close()
+	m.modelSecrets.Close()
+}
+
 func (m *ModelGithubRepository) Init() tea.Cmd {
 	go m.syncRepositories(m.syncRepositoriesContext)
 
 	openInBrowser := func() {
 		m.modelError.SetProgressMessage(fmt.Sprintf("Opening in browser..."))
 
-		err := browser.OpenInBrowser(fmt.Sprintf("https://github.com/%s", m.SelectedRepository.RepositoryName))
+		m.repositoriesMutex.RLock()
+		repositoryURL := m.repositoryURLs[m.SelectedRepository.RepositoryName]
+		m.repositoriesMutex.RUnlock()
+
+		err := browser.OpenInBrowser(repositoryURL)
 		if err != nil {
 			m.modelError.SetError(err)
 			m.modelError.SetErrorMessage(fmt.Sprintf("Cannot open in browser: %v", err))
@@ -105,6 +162,35 @@ func (m *ModelGithubRepository) Init() tea.Cmd {
 	}
 
 	m.actualModelTabOptions.AddOption("Open in browser", openInBrowser)
+	showUsage := func() {
+		if !m.tableReady || m.SelectedRepository.RepositoryName == "" {
+			return
+		}
+
+		m.modelUsage.Viewport = m.Viewport
+		m.modelUsage.Open()
+	}
+	showRunners := func() {
+		if !m.tableReady || m.SelectedRepository.RepositoryName == "" {
+			return
+		}
+
+		m.modelRunners.Viewport = m.Viewport
+		m.modelRunners.Open()
+	}
+	showSecrets := func() {
+		if !m.tableReady || m.SelectedRepository.RepositoryName == "" {
+			return
+		}
+
+		m.modelSecrets.Viewport = m.Viewport
+		m.modelSecrets.Open()
+	}
+
+	m.actualModelTabOptions.AddOption("Select branch", m.openBranchPicker)
+	m.actualModelTabOptions.AddOption("Usage", showUsage)
+	m.actualModelTabOptions.AddOption("Runners", showRunners)
+	m.actualModelTabOptions.AddOption("Secrets", showSecrets)
 
 	return nil
 }
@@ -117,7 +203,9 @@ func (m *ModelGithubRepository) syncRepositories(ctx context.Context) {
 	// delete all rows
 	m.tableGithubRepository.SetRows([]table.Row{})
 
-	repositories, err := m.githubUseCase.ListRepositories(ctx, gu.ListRepositoriesInput{})
+	repositories, err := m.githubUseCase.ListRepositories(ctx, gu.ListRepositoriesInput{
+		Sources: m.repositorySources,
+	})
 	if errors.Is(err, context.Canceled) {
 		return
 	} else if err != nil {
@@ -134,8 +222,16 @@ func (m *ModelGithubRepository) syncRepositories(ctx context.Context) {
 
 	var tableRowsGithubRepository []table.Row
 	for _, repository := range repositories.Repositories {
+		var branch = repository.DefaultBranch
+		m.repositoriesMutex.Lock()
+		if selectedBranch, ok := m.selectedBranches[repository.Name]; ok {
+			branch = selectedBranch
+		}
+		m.repositoryURLs[repository.Name] = repository.HTMLURL
+		m.repositoriesMutex.Unlock()
+
 		tableRowsGithubRepository = append(tableRowsGithubRepository,
-			table.Row{repository.Name, repository.DefaultBranch, strconv.Itoa(repository.Stars), strconv.Itoa(len(repository.Workflows))})
+			table.Row{repository.Name, branch, strconv.Itoa(repository.Stars), strconv.Itoa(len(repository.Workflows))})
 	}
 
 	m.tableGithubRepository.SetRows(tableRowsGithubRepository)
@@ -166,6 +262,28 @@ func (m *ModelGithubRepository) handleTableInputs(ctx context.Context) {
 }
 
 func (m *ModelGithubRepository) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.isBranchPickerOpen {
+		return m, m.updateBranchPicker(msg)
+	}
+
+	if m.modelUsage.IsOpen() {
+		m.modelUsage.Viewport = m.Viewport
+		_, cmd := m.modelUsage.Update(msg)
+		return m, cmd
+	}
+
+	if m.modelRunners.IsOpen() {
+		m.modelRunners.Viewport = m.Viewport
+		_, cmd := m.modelRunners.Update(msg)
+		return m, cmd
+	}
+
+	if m.modelSecrets.IsOpen() {
+		m.modelSecrets.Viewport = m.Viewport
+		_, cmd := m.modelSecrets.Update(msg)
+		return m, cmd
+	}
+
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -188,6 +306,19 @@ func (m *ModelGithubRepository) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *ModelGithubRepository) View() string {
+	if m.isBranchPickerOpen {
+		return m.viewBranchPicker()
+	}
+	if m.modelUsage.IsOpen() {
+		return m.modelUsage.View()
+	}
+	if m.modelRunners.IsOpen() {
+		return m.modelRunners.View()
+	}
+	if m.modelSecrets.IsOpen() {
+		return m.modelSecrets.View()
+	}
+
 	termWidth := m.Viewport.Width
 	termHeight := m.Viewport.Height
 
@@ -211,5 +342,14 @@ func (m *ModelGithubRepository) View() string {
 }
 
 func (m *ModelGithubRepository) ViewStatus() string {
+	if m.modelUsage.IsOpen() {
+		return m.modelUsage.ViewStatus()
+	}
+	if m.modelRunners.IsOpen() {
+		return m.modelRunners.ViewStatus()
+	}
+	if m.modelSecrets.IsOpen() {
+		return m.modelSecrets.ViewStatus()
+	}
 	return m.modelError.View()
 }
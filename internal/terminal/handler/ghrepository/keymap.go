@@ -37,6 +37,57 @@ var keys = keyMap{
 	),
 }
 
+type branchPickerKeyMap struct {
+	Navigate teakey.Binding
+	Search   teakey.Binding
+	Select   teakey.Binding
+	Cancel   teakey.Binding
+}
+
+func (k branchPickerKeyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Navigate, k.Search, k.Select, k.Cancel}
+}
+
+func (k branchPickerKeyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Navigate},
+		{k.Search},
+		{k.Select},
+		{k.Cancel},
+	}
+}
+
+var branchPickerKeys = branchPickerKeyMap{
+	Navigate: teakey.NewBinding(
+		teakey.WithKeys("up", "down"),
+		teakey.WithHelp("↑/↓", "move"),
+	),
+	Search: teakey.NewBinding(
+		teakey.WithKeys("backspace"),
+		teakey.WithHelp("type", "search branches"),
+	),
+	Select: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter", "select branch"),
+	),
+	Cancel: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "cancel"),
+	),
+}
+
 func (m *ModelGithubRepository) ViewHelp() string {
+	if m.isBranchPickerOpen {
+		return m.Help.View(branchPickerKeys)
+	}
+	if m.modelUsage.IsOpen() {
+		return m.modelUsage.ViewHelp()
+	}
+	if m.modelRunners.IsOpen() {
+		return m.modelRunners.ViewHelp()
+	}
+	if m.modelSecrets.IsOpen() {
+		return m.modelSecrets.ViewHelp()
+	}
 	return m.Help.View(m.Keys)
 }
@@ -6,7 +6,13 @@ import (
 
 var tableColumnsGithubRepository = []table.Column{
 	{Title: "Repository", Width: 24},
-	{Title: "Default Branch", Width: 16},
+	{Title: "Branch", Width: 16},
 	{Title: "Stars", Width: 6},
 	{Title: "Workflows", Width: 9},
 }
+
+var tableColumnsBranches = []table.Column{
+	{Title: "Branch", Width: 48},
+	{Title: "Protected", Width: 9},
+	{Title: "Head", Width: 7},
+}
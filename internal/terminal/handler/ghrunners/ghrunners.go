@@ -0,0 +1,249 @@
+package ghrunners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubRunners lists the self-hosted runners the selected repository can use, with their status and labels
+type ModelGithubRunners struct {
+	// current handler's properties
+	isOpen             bool
+	repository         string
+	isFilterFocused    bool
+	syncRunnersContext context.Context
+	cancelSyncRunners  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help            help.Model
+	Viewport        *viewport.Model
+	tableRunners    table.Model
+	textInputLabels textinput.Model
+	modelError      hdlerror.ModelError
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubRunners(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubRunners {
+	tableRunners := table.New(
+		table.WithColumns(tableColumnsRunners),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableRunners.SetStyles(s)
+
+	ti := textinput.New()
+	ti.Prompt = "Labels: "
+	ti.Placeholder = "self-hosted, linux"
+	ti.CharLimit = 256
+
+	return &ModelGithubRunners{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		tableRunners:       tableRunners,
+		textInputLabels:    ti,
+		syncRunnersContext: context.Background(),
+		cancelSyncRunners:  func() {},
+	}
+}
+
+func (m *ModelGithubRunners) Init() tea.Cmd {
+	return nil
+}
+
+// Open lists the runners of the selected repository and of its organization
+func (m *ModelGithubRunners) Open() {
+	m.cancelSyncRunners() // cancel previous sync
+	m.syncRunnersContext, m.cancelSyncRunners = context.WithCancel(context.Background())
+
+	m.isOpen = true
+	m.repository = m.SelectedRepository.RepositoryName
+	m.isFilterFocused = false
+	m.textInputLabels.Blur()
+	m.tableRunners.Focus()
+
+	go m.syncRunners(m.syncRunnersContext)
+}
+
+func (m *ModelGithubRunners) Close() {
+	m.cancelSyncRunners()
+	m.isOpen = false
+}
+
+func (m *ModelGithubRunners) IsOpen() bool {
+	return m.isOpen
+}
+
+// labels returns the labels of the filter, separated by commas or spaces
+func (m *ModelGithubRunners) labels() []string {
+	return strings.FieldsFunc(m.textInputLabels.Value(), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+func (m *ModelGithubRunners) syncRunners(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching runners...", m.repository))
+
+	// delete all rows
+	m.tableRunners.SetRows([]table.Row{})
+
+	output, err := m.githubUseCase.ListRunners(ctx, gu.ListRunnersInput{
+		Repository: m.repository,
+		Labels:     m.labels(),
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Runners cannot be listed")
+		return
+	}
+
+	var tableRowsRunners []table.Row
+	for _, runner := range output.Runners {
+		var scope = runner.Scope
+		if runner.Group != "" {
+			scope = fmt.Sprintf("%s · %s", runner.Scope, runner.Group)
+		}
+
+		var status = runner.Status
+		if runner.Busy {
+			status += ", busy"
+		}
+
+		tableRowsRunners = append(tableRowsRunners, table.Row{
+			runner.Name,
+			scope,
+			runner.OS,
+			status,
+			strings.Join(runner.Labels, ", "),
+		})
+	}
+
+	m.tableRunners.SetRows(tableRowsRunners)
+	m.tableRunners.SetCursor(0)
+
+	// Organization runners need the admin:org scope, say so instead of failing
+	var skipped string
+	if output.OrganizationSkipped {
+		skipped = " Organization runners are not visible to the token."
+	}
+
+	if len(output.Runners) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] No runners found.%s", m.repository, skipped))
+		return
+	}
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %d runners fetched.%s", m.repository, len(output.Runners), skipped))
+}
+
+func (m *ModelGithubRunners) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	if m.isFilterFocused {
+		switch keyMsg.String() {
+		case "tab", "enter", "esc":
+			m.isFilterFocused = false
+			m.textInputLabels.Blur()
+			m.tableRunners.Focus()
+			go m.syncRunners(m.syncRunnersContext)
+			return m, nil
+		}
+
+		m.textInputLabels, cmd = m.textInputLabels.Update(msg)
+		return m, cmd
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		m.Close()
+		return m, nil
+	case "tab":
+		m.isFilterFocused = true
+		m.tableRunners.Blur()
+		return m, m.textInputLabels.Focus()
+	case "r", "R":
+		go m.syncRunners(m.syncRunnersContext)
+		return m, nil
+	}
+
+	m.tableRunners, cmd = m.tableRunners.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubRunners) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsRunners {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsRunners
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[4].Width += widthDiff - 17
+		m.tableRunners.SetColumns(newTableColumns)
+		m.tableRunners.SetHeight(termHeight - 21)
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Runners of %s", m.repository))
+
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(termWidth - 13)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.tableRunners.View())),
+		inputStyle.Render(m.textInputLabels.View()))
+}
+
+func (m *ModelGithubRunners) ViewStatus() string {
+	return m.modelError.View()
+}
@@ -0,0 +1,42 @@
+package ghrunners
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Filter  teakey.Binding
+	Refresh teakey.Binding
+	Back    teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Filter, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Filter},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	Filter: teakey.NewBinding(
+		teakey.WithKeys("tab"),
+		teakey.WithHelp("tab", "filter by labels"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh runners"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+func (m *ModelGithubRunners) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
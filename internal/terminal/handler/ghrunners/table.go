@@ -0,0 +1,13 @@
+package ghrunners
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsRunners = []table.Column{
+	{Title: "Runner", Width: 24},
+	{Title: "Scope", Width: 22},
+	{Title: "OS", Width: 9},
+	{Title: "Status", Width: 14},
+	{Title: "Labels", Width: 32},
+}
@@ -0,0 +1,487 @@
+package ghsecrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubSecrets lists the Actions secrets and variables of a repository or of its environments, to edit them
+type ModelGithubSecrets struct {
+	// current handler's properties
+	isOpen             bool
+	repository         string
+	environments       []string
+	scopeIndex         int // 0 is the repository, the others are the environments
+	entries            []entry
+	pendingDelete      string // the entry is deleted if the delete key is pressed again on it
+	isEditing          bool
+	editing            entry
+	isNew              bool
+	isNameFocused      bool
+	syncSecretsContext context.Context
+	cancelSyncSecrets  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help           help.Model
+	Viewport       *viewport.Model
+	tableSecrets   table.Model
+	textInputName  textinput.Model
+	textInputValue textinput.Model
+	modelError     hdlerror.ModelError
+}
+
+// entry is a row of the table, a secret or a variable
+type entry struct {
+	kind      string // secret or variable
+	name      string
+	value     string // values of the secrets are never returned
+	updatedAt time.Time
+}
+
+const (
+	kindSecret   = "secret"
+	kindVariable = "variable"
+)
+
+func (e entry) key() string {
+	return e.kind + "/" + e.name
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubSecrets(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubSecrets {
+	tableSecrets := table.New(
+		table.WithColumns(tableColumnsSecrets),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableSecrets.SetStyles(s)
+
+	tiName := textinput.New()
+	tiName.Prompt = "Name: "
+	tiName.Placeholder = "DEPLOY_TOKEN"
+	tiName.CharLimit = 128
+
+	tiValue := textinput.New()
+	tiValue.Prompt = "Value: "
+	tiValue.CharLimit = 48 * 1024 // secrets are limited to 48 KB
+
+	return &ModelGithubSecrets{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		tableSecrets:       tableSecrets,
+		textInputName:      tiName,
+		textInputValue:     tiValue,
+		syncSecretsContext: context.Background(),
+		cancelSyncSecrets:  func() {},
+	}
+}
+
+func (m *ModelGithubSecrets) Init() tea.Cmd {
+	return nil
+}
+
+// Open lists the secrets and variables of the selected repository
+func (m *ModelGithubSecrets) Open() {
+	m.cancelSyncSecrets() // cancel previous sync
+	m.syncSecretsContext, m.cancelSyncSecrets = context.WithCancel(context.Background())
+
+	m.isOpen = true
+	m.repository = m.SelectedRepository.RepositoryName
+	m.environments = nil
+	m.scopeIndex = 0
+	m.pendingDelete = ""
+	m.closeForm()
+
+	go m.syncEnvironments(m.syncSecretsContext)
+	go m.syncSecrets(m.syncSecretsContext)
+}
+
+func (m *ModelGithubSecrets) Close() {
+	m.cancelSyncSecrets()
+	m.isOpen = false
+}
+
+func (m *ModelGithubSecrets) IsOpen() bool {
+	return m.isOpen
+}
+
+// environment returns the environment of the current scope, it is empty for the repository
+func (m *ModelGithubSecrets) environment() string {
+	if m.scopeIndex == 0 || m.scopeIndex > len(m.environments) {
+		return ""
+	}
+	return m.environments[m.scopeIndex-1]
+}
+
+// scope names the current scope for the messages, like "owner/repo" or "owner/repo · production"
+func (m *ModelGithubSecrets) scope() string {
+	if environment := m.environment(); environment != "" {
+		return fmt.Sprintf("%s · %s", m.repository, environment)
+	}
+	return m.repository
+}
+
+func (m *ModelGithubSecrets) syncEnvironments(ctx context.Context) {
+	// The repository scope is still usable if the environments cannot be listed
+	output, err := m.githubUseCase.ListEnvironments(ctx, gu.ListEnvironmentsInput{
+		Repository: m.repository,
+	})
+	if err != nil {
+		return
+	}
+	m.environments = output.Environments
+}
+
+func (m *ModelGithubSecrets) syncSecrets(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching secrets and variables...", m.scope()))
+
+	// delete all rows
+	m.tableSecrets.SetRows([]table.Row{})
+	m.entries = nil
+	m.pendingDelete = ""
+
+	output, err := m.githubUseCase.ListSecrets(ctx, gu.ListSecretsInput{
+		Repository:  m.repository,
+		Environment: m.environment(),
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Secrets and variables cannot be listed")
+		return
+	}
+
+	var entries []entry
+	for _, secret := range output.Secrets {
+		entries = append(entries, entry{kind: kindSecret, name: secret.Name, updatedAt: secret.UpdatedAt})
+	}
+	for _, variable := range output.Variables {
+		entries = append(entries, entry{kind: kindVariable, name: variable.Name, value: variable.Value, updatedAt: variable.UpdatedAt})
+	}
+
+	var tableRowsSecrets []table.Row
+	for _, e := range entries {
+		var value = e.value
+		if e.kind == kindSecret {
+			value = "••••••••"
+		}
+
+		var updatedAt = "-"
+		if !e.updatedAt.IsZero() {
+			updatedAt = e.updatedAt.Local().Format("2006-01-02 15:04")
+		}
+
+		tableRowsSecrets = append(tableRowsSecrets, table.Row{
+			e.kind,
+			e.name,
+			value,
+			updatedAt,
+		})
+	}
+
+	m.entries = entries
+	m.tableSecrets.SetRows(tableRowsSecrets)
+	m.tableSecrets.SetCursor(min(m.tableSecrets.Cursor(), max(len(tableRowsSecrets)-1, 0)))
+
+	if len(entries) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] No secrets or variables found.", m.scope()))
+		return
+	}
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %d secrets and %d variables fetched.",
+		m.scope(), len(output.Secrets), len(output.Variables)))
+}
+
+func (m *ModelGithubSecrets) selectedEntry() *entry {
+	cursor := m.tableSecrets.Cursor()
+	if cursor < 0 || cursor >= len(m.entries) {
+		return nil
+	}
+	return &m.entries[cursor]
+}
+
+// openForm edits an entry, a new entry starts with the name field
+func (m *ModelGithubSecrets) openForm(e entry, isNew bool) tea.Cmd {
+	m.isEditing = true
+	m.editing = e
+	m.isNew = isNew
+	m.tableSecrets.Blur()
+
+	m.textInputName.SetValue(e.name)
+	m.textInputValue.SetValue(e.value)
+
+	// Secret values are masked while they are typed, GitHub cannot return them anyway
+	if e.kind == kindSecret {
+		m.textInputValue.EchoMode = textinput.EchoPassword
+		m.textInputValue.EchoCharacter = '•'
+	} else {
+		m.textInputValue.EchoMode = textinput.EchoNormal
+	}
+
+	return m.focusName(isNew)
+}
+
+func (m *ModelGithubSecrets) focusName(focus bool) tea.Cmd {
+	m.isNameFocused = focus
+	if focus {
+		m.textInputValue.Blur()
+		return m.textInputName.Focus()
+	}
+	m.textInputName.Blur()
+	return m.textInputValue.Focus()
+}
+
+func (m *ModelGithubSecrets) closeForm() {
+	m.isEditing = false
+	m.textInputName.Blur()
+	m.textInputValue.Blur()
+	m.textInputName.SetValue("")
+	m.textInputValue.SetValue("")
+	m.tableSecrets.Focus()
+}
+
+func (m *ModelGithubSecrets) save(e entry) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Saving %s %s...", e.kind, e.name))
+
+	var err error
+	if e.kind == kindSecret {
+		_, err = m.githubUseCase.SetSecret(m.syncSecretsContext, gu.SetSecretInput{
+			Repository:  m.repository,
+			Environment: m.environment(),
+			Name:        e.name,
+			Value:       e.value,
+		})
+	} else {
+		_, err = m.githubUseCase.SetVariable(m.syncSecretsContext, gu.SetVariableInput{
+			Repository:  m.repository,
+			Environment: m.environment(),
+			Name:        e.name,
+			Value:       e.value,
+		})
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("%s %s cannot be saved", e.kind, e.name))
+		return
+	}
+
+	m.syncSecrets(m.syncSecretsContext)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %s %s is saved.", m.scope(), e.kind, e.name))
+}
+
+func (m *ModelGithubSecrets) delete(e entry) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("Deleting %s %s...", e.kind, e.name))
+
+	var err error
+	if e.kind == kindSecret {
+		_, err = m.githubUseCase.DeleteSecret(m.syncSecretsContext, gu.DeleteSecretInput{
+			Repository:  m.repository,
+			Environment: m.environment(),
+			Name:        e.name,
+		})
+	} else {
+		_, err = m.githubUseCase.DeleteVariable(m.syncSecretsContext, gu.DeleteVariableInput{
+			Repository:  m.repository,
+			Environment: m.environment(),
+			Name:        e.name,
+		})
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("%s %s cannot be deleted", e.kind, e.name))
+		return
+	}
+
+	m.syncSecrets(m.syncSecretsContext)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] %s %s is deleted.", m.scope(), e.kind, e.name))
+}
+
+func (m *ModelGithubSecrets) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	if m.isEditing {
+		return m, m.updateForm(keyMsg)
+	}
+
+	// Deleting needs the key to be pressed twice on the same entry
+	var pendingDelete = m.pendingDelete
+	m.pendingDelete = ""
+
+	switch keyMsg.String() {
+	case "esc":
+		m.Close()
+		return m, nil
+	case "r", "R":
+		go m.syncSecrets(m.syncSecretsContext)
+		return m, nil
+	case "s":
+		m.scopeIndex = (m.scopeIndex + 1) % (len(m.environments) + 1)
+		go m.syncSecrets(m.syncSecretsContext)
+		return m, nil
+	case "n":
+		return m, m.openForm(entry{kind: kindSecret}, true)
+	case "v":
+		return m, m.openForm(entry{kind: kindVariable}, true)
+	case "e", "enter":
+		if e := m.selectedEntry(); e != nil {
+			return m, m.openForm(*e, false)
+		}
+		return m, nil
+	case "x":
+		if e := m.selectedEntry(); e != nil {
+			if pendingDelete == e.key() {
+				go m.delete(*e)
+			} else {
+				m.pendingDelete = e.key()
+				m.modelError.SetDefaultMessage(fmt.Sprintf("Press x again to delete %s %s.", e.kind, e.name))
+			}
+		}
+		return m, nil
+	}
+
+	m.tableSecrets, cmd = m.tableSecrets.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubSecrets) updateForm(keyMsg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+
+	switch keyMsg.String() {
+	case "esc":
+		m.closeForm()
+		return nil
+	case "tab", "shift+tab":
+		// The name of an existing entry cannot be changed
+		if m.isNew {
+			return m.focusName(!m.isNameFocused)
+		}
+		return nil
+	case "enter":
+		e := m.editing
+		e.name = strings.TrimSpace(m.textInputName.Value())
+		e.value = m.textInputValue.Value()
+		if e.name == "" {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("The %s needs a name.", e.kind))
+			return m.focusName(true)
+		}
+
+		m.closeForm()
+		go m.save(e)
+		return nil
+	}
+
+	if m.isNameFocused {
+		m.textInputName, cmd = m.textInputName.Update(keyMsg)
+	} else {
+		m.textInputValue, cmd = m.textInputValue.Update(keyMsg)
+	}
+	return cmd
+}
+
+func (m *ModelGithubSecrets) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsSecrets {
+		tableWidth += t.Width
+	}
+
+	var tableHeight = termHeight - 18
+	if m.isEditing {
+		tableHeight -= 4 // the form takes the place of the last rows
+	}
+
+	newTableColumns := tableColumnsSecrets
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[2].Width += widthDiff - 15
+		m.tableSecrets.SetColumns(newTableColumns)
+		m.tableSecrets.SetHeight(tableHeight)
+	}
+
+	var scopeName = "repository"
+	if environment := m.environment(); environment != "" {
+		scopeName = "environment " + environment
+	}
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Secrets and variables of %s", m.repository)) +
+		"  " + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(
+		fmt.Sprintf("%s (%d environments)", scopeName, len(m.environments)))
+
+	view := baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.tableSecrets.View()))
+	if !m.isEditing {
+		return view
+	}
+
+	var title = "Edit " + m.editing.kind + " " + m.editing.name
+	if m.isNew {
+		title = "New " + m.editing.kind
+	}
+
+	formStyle := lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		Padding(0, 1).
+		Width(termWidth - 7)
+
+	return lipgloss.JoinVertical(lipgloss.Top, view, formStyle.Render(lipgloss.JoinVertical(lipgloss.Top,
+		lipgloss.NewStyle().Bold(true).Render(title),
+		m.textInputName.View(),
+		m.textInputValue.View())))
+}
+
+func (m *ModelGithubSecrets) ViewStatus() string {
+	return m.modelError.View()
+}
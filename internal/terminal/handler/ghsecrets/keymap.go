@@ -0,0 +1,102 @@
+package ghsecrets
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	NewSecret   teakey.Binding
+	NewVariable teakey.Binding
+	Edit        teakey.Binding
+	Delete      teakey.Binding
+	SwitchScope teakey.Binding
+	Refresh     teakey.Binding
+	Back        teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.NewSecret, k.NewVariable, k.Edit, k.Delete, k.SwitchScope, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.NewSecret},
+		{k.NewVariable},
+		{k.Edit},
+		{k.Delete},
+		{k.SwitchScope},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	NewSecret: teakey.NewBinding(
+		teakey.WithKeys("n"),
+		teakey.WithHelp("n", "new secret"),
+	),
+	NewVariable: teakey.NewBinding(
+		teakey.WithKeys("v"),
+		teakey.WithHelp("v", "new variable"),
+	),
+	Edit: teakey.NewBinding(
+		teakey.WithKeys("e", "enter"),
+		teakey.WithHelp("e/enter", "edit"),
+	),
+	Delete: teakey.NewBinding(
+		teakey.WithKeys("x"),
+		teakey.WithHelp("x x", "delete"),
+	),
+	SwitchScope: teakey.NewBinding(
+		teakey.WithKeys("s"),
+		teakey.WithHelp("s", "switch environment"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+type formKeyMap struct {
+	SwitchField teakey.Binding
+	Save        teakey.Binding
+	Cancel      teakey.Binding
+}
+
+func (k formKeyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.SwitchField, k.Save, k.Cancel}
+}
+
+func (k formKeyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.SwitchField},
+		{k.Save},
+		{k.Cancel},
+	}
+}
+
+var formKeys = formKeyMap{
+	SwitchField: teakey.NewBinding(
+		teakey.WithKeys("tab"),
+		teakey.WithHelp("tab", "switch field"),
+	),
+	Save: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter", "save"),
+	),
+	Cancel: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "cancel"),
+	),
+}
+
+func (m *ModelGithubSecrets) ViewHelp() string {
+	if m.isEditing {
+		return m.Help.View(formKeys)
+	}
+	return m.Help.View(m.Keys)
+}
@@ -0,0 +1,12 @@
+package ghsecrets
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsSecrets = []table.Column{
+	{Title: "Kind", Width: 10},
+	{Title: "Name", Width: 28},
+	{Title: "Value", Width: 32},
+	{Title: "Updated", Width: 19},
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -15,6 +16,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	"github.com/termkit/gama/internal/terminal/handler/ghworkflowrun"
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	"github.com/termkit/gama/pkg/workflow"
 )
@@ -23,6 +25,9 @@ type ModelGithubTrigger struct {
 	// current handler's properties
 	syncWorkflowContext        context.Context
 	cancelSyncWorkflow         context.CancelFunc
+	triggerContext             context.Context // lookup of the triggered run, cancelled when the tab is closed
+	cancelTrigger              context.CancelFunc
+	triggering                 atomic.Bool // set while a trigger waits for its run
 	workflowContent            *workflow.Pretty
 	tableReady                 bool
 	isTriggerable              bool
@@ -34,6 +39,7 @@ type ModelGithubTrigger struct {
 	currentOption              string
 	selectedWorkflow           string
 	selectedRepositoryName     string
+	selectedBranchName         string
 	triggerFocused             bool
 
 	// shared properties
@@ -51,6 +57,8 @@ type ModelGithubTrigger struct {
 	modelError   hdlerror.ModelError
 	textInput    textinput.Model
 	tableTrigger table.Model
+
+	modelWorkflowRun *ghworkflowrun.ModelGithubWorkflowRun
 }
 
 func SetupModelGithubTrigger(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository, currentTab *int, forceUpdateWorkflowHistory *bool) *ModelGithubTrigger {
@@ -79,6 +87,8 @@ func SetupModelGithubTrigger(githubUseCase gu.UseCase, selectedRepository *hdlty
 	ti.Blur()
 	ti.CharLimit = 72
 
+	triggerContext, cancelTrigger := context.WithCancel(context.Background())
+
 	return &ModelGithubTrigger{
 		currentTab:                 currentTab,
 		forceUpdateWorkflowHistory: forceUpdateWorkflowHistory,
@@ -91,21 +101,39 @@ func SetupModelGithubTrigger(githubUseCase gu.UseCase, selectedRepository *hdlty
 		textInput:                  ti,
 		syncWorkflowContext:        context.Background(),
 		cancelSyncWorkflow:         func() {},
+		triggerContext:             triggerContext,
+		cancelTrigger:              cancelTrigger,
+		modelWorkflowRun:           ghworkflowrun.SetupModelGithubWorkflowRun(githubUseCase, selectedRepository),
 	}
 }
 
+// Close stops the workflow sync and the lookup of a triggered run, the model is not used afterward
+func (m *ModelGithubTrigger) Close() {
+	m.cancelSyncWorkflow()
+	m.cancelTrigger()
+	m.modelWorkflowRun.Close()
+}
+
 func (m *ModelGithubTrigger) Init() tea.Cmd {
 	m.modelError.SetDefaultMessage("No workflow contents found.")
 	return textinput.Blink
 }
 
 func (m *ModelGithubTrigger) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.modelWorkflowRun.IsOpen() {
+		m.modelWorkflowRun.Viewport = m.Viewport
+		_, cmd := m.modelWorkflowRun.Update(msg)
+		return m, cmd
+	}
+
 	if m.SelectedRepository.WorkflowName == "" {
 		m.modelError.Reset()
 		m.modelError.SetDefaultMessage("No workflow selected.")
 		return m, nil
 	}
-	if m.SelectedRepository.WorkflowName != "" && (m.SelectedRepository.WorkflowName != m.selectedWorkflow || m.SelectedRepository.RepositoryName != m.selectedRepositoryName) {
+	if m.SelectedRepository.WorkflowName != "" && (m.SelectedRepository.WorkflowName != m.selectedWorkflow ||
+		m.SelectedRepository.RepositoryName != m.selectedRepositoryName ||
+		m.SelectedRepository.BranchName != m.selectedBranchName) {
 		m.tableReady = false
 		m.isTriggerable = false
 		m.triggerFocused = false
@@ -114,6 +142,7 @@ func (m *ModelGithubTrigger) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.selectedWorkflow = m.SelectedRepository.WorkflowName
 		m.selectedRepositoryName = m.SelectedRepository.RepositoryName
+		m.selectedBranchName = m.SelectedRepository.BranchName
 		m.syncWorkflowContext, m.cancelSyncWorkflow = context.WithCancel(context.Background())
 
 		go m.syncWorkflowContent(m.syncWorkflowContext)
@@ -167,7 +196,12 @@ func (m *ModelGithubTrigger) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "enter":
 			if m.triggerFocused && m.isTriggerable {
-				go m.triggerWorkflow()
+				// The run of the previous trigger is still looked for
+				if !m.triggering.CompareAndSwap(false, true) {
+					m.modelError.SetDefaultMessage("Waiting for the run of the triggered workflow...")
+					break
+				}
+				go m.triggerWorkflow(m.triggerContext)
 			}
 		}
 	}
@@ -310,6 +344,10 @@ func (m *ModelGithubTrigger) inputController(ctx context.Context) {
 }
 
 func (m *ModelGithubTrigger) View() string {
+	if m.modelWorkflowRun.IsOpen() {
+		return m.modelWorkflowRun.View()
+	}
+
 	baseStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderForeground(lipgloss.Color("240"))
@@ -514,7 +552,9 @@ func (m *ModelGithubTrigger) fillEmptyValuesWithDefault() {
 	}
 }
 
-func (m *ModelGithubTrigger) triggerWorkflow() {
+func (m *ModelGithubTrigger) triggerWorkflow(ctx context.Context) {
+	defer m.triggering.Store(false)
+
 	if m.triggerFocused {
 		m.fillEmptyValuesWithDefault()
 	}
@@ -535,24 +575,26 @@ func (m *ModelGithubTrigger) triggerWorkflow() {
 		return
 	}
 
-	_, err = m.githubUseCase.TriggerWorkflow(context.Background(), gu.TriggerWorkflowInput{
-		Repository:   m.SelectedRepository.RepositoryName,
-		Branch:       m.SelectedRepository.BranchName,
-		WorkflowFile: m.selectedWorkflow,
+	var repositoryName = m.SelectedRepository.RepositoryName
+	var branchName = m.SelectedRepository.BranchName
+	var workflowName = m.selectedWorkflow
+
+	dispatchedWorkflow, err := m.githubUseCase.DispatchWorkflow(ctx, gu.DispatchWorkflowInput{
+		Repository:   repositoryName,
+		Branch:       branchName,
+		WorkflowFile: workflowName,
 		Content:      content,
 	})
-	if err != nil {
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
 		m.modelError.SetError(err)
 		m.modelError.SetErrorMessage("Workflow cannot be triggered")
 		return
 	}
 
-	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s]:[%s] Workflow triggered.",
-		m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName, m.selectedWorkflow))
-
-	time.Sleep(1 * time.Second)
-	m.modelError.SetProgressMessage("Switching to workflow history tab...")
-	time.Sleep(1 * time.Second)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s]:[%s] Workflow triggered, looking for its run...",
+		repositoryName, branchName, workflowName))
 
 	// move these operations under new function named "resetTabSettings"
 	m.workflowContent = nil       // reset workflow content
@@ -560,11 +602,37 @@ func (m *ModelGithubTrigger) triggerWorkflow() {
 	m.currentOption = ""          // reset current option
 	m.optionValues = nil          // reset option values
 	m.selectedRepositoryName = "" // reset selected repository name
+	m.selectedBranchName = ""     // reset selected branch name
+
+	*m.forceUpdateWorkflowHistory = true // force update workflow history
+
+	dispatchedRun, err := m.githubUseCase.FindDispatchedRun(ctx, gu.FindDispatchedRunInput{
+		Repository:    repositoryName,
+		Branch:        branchName,
+		WorkflowFile:  workflowName,
+		DispatchedAt:  dispatchedWorkflow.DispatchedAt,
+		CorrelationID: dispatchedWorkflow.CorrelationID,
+	})
+	if ctx.Err() != nil {
+		// The tab is closed, the workflow is triggered but its run is not followed
+		return
+	}
+
+	if err == nil && dispatchedRun.RunID != 0 {
+		// Jump to the run, and watch it until it completes
+		m.modelWorkflowRun.Viewport = m.Viewport
+		m.modelWorkflowRun.Open(dispatchedRun.RunID, workflowName)
+		return
+	}
 
-	go func() {
-		time.Sleep(1 * time.Second)
-		*m.forceUpdateWorkflowHistory = true // force update workflow history
-	}()
+	m.modelError.SetDefaultMessage("Run of the workflow is not found yet")
+	time.Sleep(1 * time.Second)
+	m.modelError.SetProgressMessage("Switching to workflow history tab...")
+	time.Sleep(1 * time.Second)
+
+	if ctx.Err() != nil {
+		return
+	}
 	*m.currentTab = 2 // switch tab to workflow history
 }
 
@@ -630,5 +698,8 @@ func (m *ModelGithubTrigger) optionSelector() string {
 }
 
 func (m *ModelGithubTrigger) ViewStatus() string {
+	if m.modelWorkflowRun.IsOpen() {
+		return m.modelWorkflowRun.ViewStatus()
+	}
 	return m.modelError.View()
 }
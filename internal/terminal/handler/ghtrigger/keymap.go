@@ -44,5 +44,8 @@ var keys = keyMap{
 }
 
 func (m *ModelGithubTrigger) ViewHelp() string {
+	if m.modelWorkflowRun.IsOpen() {
+		return m.modelWorkflowRun.ViewHelp()
+	}
 	return m.Help.View(m.Keys)
 }
@@ -0,0 +1,287 @@
+package ghusage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubUsage shows the billable minutes of the workflows of a repository over a time window
+type ModelGithubUsage struct {
+	// current handler's properties
+	isOpen           bool
+	repository       string
+	windowIndex      int
+	usage            *gu.GetUsageOutput
+	syncUsageContext context.Context
+	cancelSyncUsage  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help       help.Model
+	Viewport   *viewport.Model
+	tableUsage table.Model
+	modelError hdlerror.ModelError
+}
+
+// usageWindows are the time windows the usage is calculated for, in days
+var usageWindows = []int{7, 30, 90}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubUsage(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubUsage {
+	tableUsage := table.New(
+		table.WithColumns(tableColumnsUsage),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableUsage.SetStyles(s)
+
+	return &ModelGithubUsage{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		tableUsage:         tableUsage,
+		windowIndex:        1, // 30 days
+		syncUsageContext:   context.Background(),
+		cancelSyncUsage:    func() {},
+	}
+}
+
+func (m *ModelGithubUsage) Init() tea.Cmd {
+	return nil
+}
+
+// Open calculates the usage of the selected repository
+func (m *ModelGithubUsage) Open() {
+	m.isOpen = true
+	m.repository = m.SelectedRepository.RepositoryName
+
+	m.startSyncUsage()
+}
+
+func (m *ModelGithubUsage) Close() {
+	m.cancelSyncUsage()
+	m.isOpen = false
+}
+
+func (m *ModelGithubUsage) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m *ModelGithubUsage) startSyncUsage() {
+	m.cancelSyncUsage() // cancel previous sync
+	m.syncUsageContext, m.cancelSyncUsage = context.WithCancel(context.Background())
+
+	go m.syncUsage(m.syncUsageContext)
+}
+
+func (m *ModelGithubUsage) syncUsage(ctx context.Context) {
+	var days = usageWindows[m.windowIndex]
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Calculating the usage of the last %d days...", m.repository, days))
+
+	// delete all rows
+	m.tableUsage.SetRows([]table.Row{})
+	m.usage = nil
+
+	usage, err := m.githubUseCase.GetUsage(ctx, gu.GetUsageInput{
+		Repositories: []string{m.repository},
+		CreatedAfter: time.Now().AddDate(0, 0, -days),
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Usage cannot be calculated")
+		return
+	}
+
+	var tableRowsUsage []table.Row
+	for _, workflow := range usage.Workflows {
+		var byOS []string
+		for _, osUsage := range usage.OperatingSystems {
+			if ms, ok := workflow.BillableMSByOS[osUsage.OS]; ok {
+				byOS = append(byOS, fmt.Sprintf("%s %s", osUsage.OS, gu.FormatMinutes(ms)))
+			}
+		}
+
+		tableRowsUsage = append(tableRowsUsage, table.Row{
+			workflow.Workflow,
+			fmt.Sprintf("%d", workflow.Runs),
+			gu.FormatMinutes(workflow.BillableMS),
+			gu.FormatMinutes(workflow.RunDurationMS),
+			gu.FormatMinutes(workflow.BillingCycleMS),
+			strings.Join(byOS, " · "),
+		})
+	}
+
+	m.usage = usage
+	m.tableUsage.SetRows(tableRowsUsage)
+	m.tableUsage.SetCursor(0)
+
+	if len(usage.Workflows) == 0 {
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] No completed runs in the last %d days.", m.repository, days))
+		return
+	}
+	if usage.Truncated {
+		var counted int
+		for _, repositoryUsage := range usage.Repositories {
+			counted += repositoryUsage.Runs
+		}
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] Only the latest %d of %d runs of the last %d days are counted, the usage is understated.",
+			m.repository, counted, usage.TotalRuns, days))
+		return
+	}
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Usage of the last %d days calculated.", m.repository, days))
+}
+
+// export writes the usage to a file in the working directory, in CSV or JSON format
+func (m *ModelGithubUsage) export(format string) {
+	if m.usage == nil {
+		return
+	}
+
+	var name = fmt.Sprintf("gama-usage-%s-%s.%s",
+		strings.ReplaceAll(m.repository, "/", "-"), time.Now().Format("20060102"), format)
+
+	directory, err := os.Getwd()
+	if err != nil {
+		directory = "."
+	}
+	path := filepath.Join(directory, name)
+
+	err = writeFile(path, func(w io.Writer) error {
+		if format == "csv" {
+			return m.usage.WriteCSV(w)
+		}
+		return m.usage.WriteJSON(w)
+	})
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Usage cannot be exported")
+		return
+	}
+
+	m.modelError.SetSuccessMessage(fmt.Sprintf("Usage is exported to %s", path))
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (m *ModelGithubUsage) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		m.Close()
+		return m, nil
+	case "r", "R":
+		m.startSyncUsage()
+		return m, nil
+	case "w":
+		m.windowIndex = (m.windowIndex + 1) % len(usageWindows)
+		m.startSyncUsage()
+		return m, nil
+	case "c":
+		m.export("csv")
+		return m, nil
+	case "j":
+		m.export("json")
+		return m, nil
+	}
+
+	m.tableUsage, cmd = m.tableUsage.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubUsage) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsUsage {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsUsage
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[0].Width += widthDiff - 19
+		m.tableUsage.SetColumns(newTableColumns)
+		m.tableUsage.SetHeight(termHeight - 18)
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render(
+		fmt.Sprintf("Usage of %s in the last %d days", m.repository, usageWindows[m.windowIndex]))
+	if m.usage != nil && len(m.usage.Repositories) > 0 {
+		var totals = []string{fmt.Sprintf("%s billable min", gu.FormatMinutes(m.usage.Repositories[0].BillableMS))}
+		if repositoryUsage := m.usage.Repositories[0]; repositoryUsage.Truncated {
+			totals = append(totals, fmt.Sprintf("%d of %d runs", repositoryUsage.Runs, repositoryUsage.TotalRuns))
+		}
+		for _, osUsage := range m.usage.OperatingSystems {
+			totals = append(totals, fmt.Sprintf("%s %s", osUsage.OS, gu.FormatMinutes(osUsage.BillableMS)))
+		}
+		header += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(strings.Join(totals, " · "))
+	}
+
+	return baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.tableUsage.View()))
+}
+
+func (m *ModelGithubUsage) ViewStatus() string {
+	return m.modelError.View()
+}
@@ -0,0 +1,54 @@
+package ghusage
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Window     teakey.Binding
+	ExportCSV  teakey.Binding
+	ExportJSON teakey.Binding
+	Refresh    teakey.Binding
+	Back       teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Window, k.ExportCSV, k.ExportJSON, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Window},
+		{k.ExportCSV},
+		{k.ExportJSON},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	Window: teakey.NewBinding(
+		teakey.WithKeys("w"),
+		teakey.WithHelp("w", "change window"),
+	),
+	ExportCSV: teakey.NewBinding(
+		teakey.WithKeys("c"),
+		teakey.WithHelp("c", "export csv"),
+	),
+	ExportJSON: teakey.NewBinding(
+		teakey.WithKeys("j"),
+		teakey.WithHelp("j", "export json"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh usage"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+func (m *ModelGithubUsage) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
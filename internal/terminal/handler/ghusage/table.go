@@ -0,0 +1,14 @@
+package ghusage
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsUsage = []table.Column{
+	{Title: "Workflow", Width: 24},
+	{Title: "Runs", Width: 6},
+	{Title: "Billable min", Width: 12},
+	{Title: "Wall-clock min", Width: 14},
+	{Title: "Cycle min", Width: 10},
+	{Title: "By OS", Width: 24},
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/table"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
 	"github.com/termkit/gama/internal/terminal/handler/ghtrigger"
+	"github.com/termkit/gama/internal/terminal/handler/ghworkflows"
 	"github.com/termkit/gama/internal/terminal/handler/taboptions"
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 
@@ -26,6 +29,11 @@ type ModelGithubWorkflow struct {
 	cancelSyncTriggerableWorkflows  context.CancelFunc
 	tableReady                      bool
 	lastRepository                  string
+	lastBranch                      string
+	currentTab                      *int
+	showWorkflowRuns                func(workflowFile string)
+	recentRuns                      map[string][]gu.Workflow // recent runs of the workflows by their file
+	recentRunsMutex                 sync.RWMutex
 
 	// shared properties
 	SelectedRepository *hdltypes.SelectedRepository
@@ -48,13 +56,16 @@ type ModelGithubWorkflow struct {
 
 	modelGithubTrigger       tea.Model
 	actualModelGithubTrigger *ghtrigger.ModelGithubTrigger
+
+	modelWorkflows *ghworkflows.ModelGithubWorkflows
 }
 
 var baseStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
 	BorderForeground(lipgloss.Color("240"))
 
-func SetupModelGithubWorkflow(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubWorkflow {
+// SetupModelGithubWorkflow sets up the workflow tab, showWorkflowRuns is called to show the history of a workflow
+func SetupModelGithubWorkflow(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository, currentTab *int, showWorkflowRuns func(workflowFile string)) *ModelGithubWorkflow {
 	var tableRowsTriggerableWorkflow []table.Row
 
 	tableTriggerableWorkflow := table.New(
@@ -86,36 +97,79 @@ func SetupModelGithubWorkflow(githubUseCase gu.UseCase, selectedRepository *hdlt
 		SelectedRepository:              selectedRepository,
 		modelTabOptions:                 tabOptions,
 		actualModelTabOptions:           tabOptions,
+		currentTab:                      currentTab,
+		showWorkflowRuns:                showWorkflowRuns,
+		recentRuns:                      make(map[string][]gu.Workflow),
+		modelWorkflows:                  ghworkflows.SetupModelGithubWorkflows(githubUseCase, selectedRepository),
 		syncTriggerableWorkflowsContext: context.Background(),
 		cancelSyncTriggerableWorkflows:  func() {},
 	}
 }
 
+// Close stops the sync of the workflows and of their recent runs, and closes the workflows window
+func (m *ModelGithubWorkflow) Close() {
+	m.cancelSyncTriggerableWorkflows()
+	m.modelWorkflows.Close()
+}
+
 func (m *ModelGithubWorkflow) Init() tea.Cmd {
-	return nil
+	showRuns := func() {
+		if !m.tableReady || m.SelectedRepository.WorkflowName == "" {
+			return
+		}
+
+		m.showWorkflowRuns(m.SelectedRepository.WorkflowName)
+		*m.currentTab = 2 // switch tab to workflow history
+	}
+
+	showAllWorkflows := func() {
+		m.modelWorkflows.Viewport = m.Viewport
+		m.modelWorkflows.Open()
+	}
+
+	m.actualModelTabOptions.AddOption("Show runs", showRuns)
+	m.actualModelTabOptions.AddOption("All workflows", showAllWorkflows)
+
+	return m.modelTabOptions.Init()
 }
 
 func (m *ModelGithubWorkflow) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
-	if m.lastRepository != m.SelectedRepository.RepositoryName {
+	if m.lastRepository != m.SelectedRepository.RepositoryName || m.lastBranch != m.SelectedRepository.BranchName {
 		m.tableReady = false               // reset table ready status
 		m.cancelSyncTriggerableWorkflows() // cancel previous sync
 		m.syncTriggerableWorkflowsContext, m.cancelSyncTriggerableWorkflows = context.WithCancel(context.Background())
 
 		m.lastRepository = m.SelectedRepository.RepositoryName
+		m.lastBranch = m.SelectedRepository.BranchName
 
 		go m.syncTriggerableWorkflows(m.syncTriggerableWorkflowsContext)
 	}
 
+	if m.modelWorkflows.IsOpen() {
+		m.modelWorkflows.Viewport = m.Viewport
+		_, cmd = m.modelWorkflows.Update(msg)
+		return m, cmd
+	}
+
+	m.modelTabOptions, cmd = m.modelTabOptions.Update(msg)
+	cmds = append(cmds, cmd)
+
 	m.tableTriggerableWorkflow, cmd = m.tableTriggerableWorkflow.Update(msg)
+	cmds = append(cmds, cmd)
 
 	m.handleTableInputs(m.syncTriggerableWorkflowsContext) // update table operations
 
-	return m, cmd
+	return m, tea.Batch(cmds...)
 }
 
 func (m *ModelGithubWorkflow) View() string {
+	if m.modelWorkflows.IsOpen() {
+		return m.modelWorkflows.View()
+	}
+
 	termWidth := m.Viewport.Width
 	termHeight := m.Viewport.Height
 
@@ -129,13 +183,33 @@ func (m *ModelGithubWorkflow) View() string {
 	if widthDiff > 0 {
 		newTableColumns[1].Width += widthDiff - 11
 		m.tableTriggerableWorkflow.SetColumns(newTableColumns)
-		m.tableTriggerableWorkflow.SetHeight(termHeight - 17)
+		m.tableTriggerableWorkflow.SetHeight(termHeight - 18)
 	}
 
 	doc := strings.Builder{}
 	doc.WriteString(baseStyle.Render(m.tableTriggerableWorkflow.View()))
+	doc.WriteString("\n")
+	doc.WriteString(m.viewRecentRuns())
 
-	return doc.String()
+	return lipgloss.JoinVertical(lipgloss.Top, doc.String(), m.actualModelTabOptions.View())
+}
+
+// viewRecentRuns renders the sparkline of the recent runs of the selected workflow
+func (m *ModelGithubWorkflow) viewRecentRuns() string {
+	if !m.tableReady || m.SelectedRepository.WorkflowName == "" {
+		return ""
+	}
+
+	m.recentRunsMutex.RLock()
+	runs, ok := m.recentRuns[m.SelectedRepository.WorkflowName]
+	m.recentRunsMutex.RUnlock()
+
+	var title = fmt.Sprintf(" Recent runs of %s: ", path.Base(m.SelectedRepository.WorkflowName))
+	if !ok {
+		return title + styleMuted.Render("fetching...")
+	}
+
+	return title + sparkline(runs) + " " + styleMuted.Render(summarizeRuns(runs))
 }
 
 func (m *ModelGithubWorkflow) syncTriggerableWorkflows(ctx context.Context) {
@@ -175,12 +249,61 @@ func (m *ModelGithubWorkflow) syncTriggerableWorkflows(ctx context.Context) {
 
 	m.tableTriggerableWorkflow.SetRows(tableRowsTriggerableWorkflow)
 
+	m.recentRunsMutex.Lock()
+	m.recentRuns = make(map[string][]gu.Workflow)
+	m.recentRunsMutex.Unlock()
+
+	var workflowFiles []string
+	for _, workflow := range triggerableWorkflows.TriggerableWorkflows {
+		workflowFiles = append(workflowFiles, workflow.Path)
+	}
+	go m.syncRecentRuns(ctx, m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName, workflowFiles)
+
 	m.tableReady = true
 	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s] Triggerable workflows fetched.", m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName))
 
 	go m.Update(m) // update model
 }
 
+// syncRecentRuns fetches the recent runs of the workflows for their sparklines, a few workflows at a time.
+// The repository and the branch are given since the selection may change meanwhile, failures leave the sparkline empty.
+func (m *ModelGithubWorkflow) syncRecentRuns(ctx context.Context, repository string, branch string, workflowFiles []string) {
+	var wg sync.WaitGroup
+	var semaphore = make(chan struct{}, maxRecentRunsWorkers)
+
+	for _, workflowFile := range workflowFiles {
+		if ctx.Err() != nil {
+			break
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(workflowFile string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			history, err := m.githubUseCase.GetWorkflowHistory(ctx, gu.GetWorkflowHistoryInput{
+				Repository: repository,
+				Branch:     branch,
+				Limit:      recentRunsLimit,
+				Filter:     gu.HistoryFilter{Workflow: workflowFile},
+			})
+			if err != nil {
+				return
+			}
+
+			m.recentRunsMutex.Lock()
+			if ctx.Err() == nil { // a newer sync owns the recent runs
+				m.recentRuns[workflowFile] = history.Workflows
+			}
+			m.recentRunsMutex.Unlock()
+		}(workflowFile)
+	}
+	wg.Wait()
+}
+
 func (m *ModelGithubWorkflow) handleTableInputs(ctx context.Context) {
 	if !m.tableReady {
 		return
@@ -198,5 +321,8 @@ func (m *ModelGithubWorkflow) handleTableInputs(ctx context.Context) {
 }
 
 func (m *ModelGithubWorkflow) ViewStatus() string {
+	if m.modelWorkflows.IsOpen() {
+		return m.modelWorkflows.ViewStatus()
+	}
 	return m.modelError.View()
 }
@@ -6,15 +6,17 @@ import (
 
 type keyMap struct {
 	TabSwitch teakey.Binding
+	LaunchTab teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.TabSwitch}
+	return []teakey.Binding{k.TabSwitch, k.LaunchTab}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
 	return [][]teakey.Binding{
 		{k.TabSwitch},
+		{k.LaunchTab},
 	}
 }
 
@@ -23,8 +25,15 @@ var keys = keyMap{
 		teakey.WithKeys("shift+left", "shift+right"),
 		teakey.WithHelp("shift + (← | →)", "switch tab"),
 	),
+	LaunchTab: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter", "Launch the selected option"),
+	),
 }
 
 func (m *ModelGithubWorkflow) ViewHelp() string {
+	if m.modelWorkflows.IsOpen() {
+		return m.modelWorkflows.ViewHelp()
+	}
 	return m.Help.View(m.Keys)
 }
@@ -0,0 +1,72 @@
+package ghworkflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+)
+
+// recentRunsLimit is the number of runs shown in the sparkline of a workflow
+const recentRunsLimit = 20
+
+// maxRecentRunsWorkers is the number of workflows whose recent runs are fetched concurrently
+const maxRecentRunsWorkers = 8
+
+var (
+	styleSuccess  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	styleFailure  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	styleProgress = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	styleMuted    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// sparkline renders the conclusions of the runs from the oldest to the newest, the runs are given newest first
+func sparkline(runs []gu.Workflow) string {
+	var bars strings.Builder
+	for i := len(runs) - 1; i >= 0; i-- {
+		bars.WriteString(conclusionBar(runs[i].Status, runs[i].Conclusion))
+	}
+	return bars.String()
+}
+
+// conclusionBar returns the bar of a run, failures are the tallest to stand out
+func conclusionBar(status string, conclusion string) string {
+	if status != "completed" {
+		return styleProgress.Render("▄")
+	}
+
+	switch conclusion {
+	case "success":
+		return styleSuccess.Render("▃")
+	case "failure", "timed_out", "startup_failure":
+		return styleFailure.Render("█")
+	default: // cancelled, skipped, neutral, action_required, stale
+		return styleMuted.Render("▁")
+	}
+}
+
+// summarizeRuns describes the recent runs of a workflow, like "3 of the last 20 runs failed".
+// Only successful runs count as passed, cancelled, skipped and running ones do not.
+func summarizeRuns(runs []gu.Workflow) string {
+	var passed, failed int
+	for _, run := range runs {
+		switch run.Conclusion {
+		case "success":
+			passed++
+		case "failure", "timed_out", "startup_failure":
+			failed++
+		}
+	}
+
+	switch {
+	case len(runs) == 0:
+		return "no runs yet"
+	case passed == len(runs):
+		return fmt.Sprintf("the last %d runs passed", len(runs))
+	case failed > 0:
+		return fmt.Sprintf("%d of the last %d runs failed", failed, len(runs))
+	default:
+		return fmt.Sprintf("%d of the last %d runs passed", passed, len(runs))
+	}
+}
@@ -0,0 +1,105 @@
+package ghworkflowhistory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gu "github.com/termkit/gama/internal/github/usecase"
+)
+
+// filterDateLayout is the layout of the dates in the created filter
+const filterDateLayout = "2006-01-02"
+
+// parseHistoryFilter parses a filter query like "workflow:deploy.yml status:failure created:>=2024-01-01".
+//
+// Supported keys are workflow, status, event, actor, sha, exclude_prs and created.
+// created accepts a single day, a range like 2024-01-01..2024-01-31, or a bound like >=2024-01-01 or <=2024-01-31.
+func parseHistoryFilter(query string) (gu.HistoryFilter, error) {
+	var filter gu.HistoryFilter
+
+	for _, field := range strings.Fields(query) {
+		key, value, found := strings.Cut(field, ":")
+		if !found || value == "" {
+			return gu.HistoryFilter{}, fmt.Errorf("filter %q must be in key:value form", field)
+		}
+
+		switch strings.ToLower(key) {
+		case "workflow":
+			filter.Workflow = value
+		case "status":
+			filter.Status = value
+		case "event":
+			filter.Event = value
+		case "actor":
+			filter.Actor = value
+		case "sha":
+			filter.HeadSHA = value
+		case "exclude_prs":
+			exclude, err := strconv.ParseBool(value)
+			if err != nil {
+				return gu.HistoryFilter{}, fmt.Errorf("exclude_prs must be true or false, got %q", value)
+			}
+			filter.ExcludePullRequests = exclude
+		case "created":
+			after, before, err := parseCreatedFilter(value)
+			if err != nil {
+				return gu.HistoryFilter{}, err
+			}
+			filter.CreatedAfter, filter.CreatedBefore = after, before
+		default:
+			return gu.HistoryFilter{}, fmt.Errorf("unknown filter key %q", key)
+		}
+	}
+
+	return filter, nil
+}
+
+// parseCreatedFilter parses the value of the created filter into its bounds, a zero bound is open
+func parseCreatedFilter(value string) (time.Time, time.Time, error) {
+	var after, before time.Time
+	var err error
+
+	switch {
+	case strings.HasPrefix(value, ">="):
+		after, err = parseFilterDate(value[2:])
+	case strings.HasPrefix(value, "<="):
+		before, err = parseFilterDate(value[2:])
+		before = endOfDay(before)
+	case strings.Contains(value, ".."):
+		from, to, _ := strings.Cut(value, "..")
+		if after, err = parseFilterDate(from); err != nil {
+			break
+		}
+		before, err = parseFilterDate(to)
+		before = endOfDay(before)
+		if err == nil && before.Before(after) {
+			err = fmt.Errorf("created range %q ends before it starts", value)
+		}
+	default:
+		after, err = parseFilterDate(value)
+		before = endOfDay(after)
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return after, before, nil
+}
+
+func parseFilterDate(value string) (time.Time, error) {
+	date, err := time.ParseInLocation(filterDateLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q must be in YYYY-MM-DD form", value)
+	}
+	return date, nil
+}
+
+// endOfDay returns the last second of the day that starts at the given time
+func endOfDay(day time.Time) time.Time {
+	if day.IsZero() {
+		return day
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Second)
+}
@@ -4,15 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	"github.com/termkit/gama/internal/terminal/handler/ghartifacts"
+	"github.com/termkit/gama/internal/terminal/handler/ghdeployments"
+	"github.com/termkit/gama/internal/terminal/handler/ghworkflowrun"
 	"github.com/termkit/gama/internal/terminal/handler/taboptions"
 	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	"github.com/termkit/gama/pkg/browser"
@@ -23,11 +28,18 @@ type ModelGithubWorkflowHistory struct {
 	tableReady                 bool
 	updateRound                int
 	selectedWorkflowID         int64
+	selectedWorkflowURL        string
 	isTableFocused             bool
 	lastRepository             string
+	lastBranch                 string
 	forceUpdate                *bool
 	syncWorkflowHistoryContext context.Context
 	cancelSyncWorkflowHistory  context.CancelFunc
+	ctx                        context.Context // the context of the model, it is cancelled when the profile is switched
+	cancel                     context.CancelFunc
+	filter                     gu.HistoryFilter
+	filterQuery                string
+	isFilterFocused            bool
 	Workflows                  []gu.Workflow
 
 	// shared properties
@@ -43,12 +55,20 @@ type ModelGithubWorkflowHistory struct {
 	Help                 help.Model
 	Viewport             *viewport.Model
 	tableWorkflowHistory table.Model
+	textInputFilter      textinput.Model
 	modelError           hdlerror.ModelError
 
 	modelTabOptions       tea.Model
 	actualModelTabOptions *taboptions.Options
+
+	modelWorkflowRun *ghworkflowrun.ModelGithubWorkflowRun
+	modelArtifacts   *ghartifacts.ModelGithubArtifacts
+	modelDeployments *ghdeployments.ModelGithubDeployments
 }
 
+// workflowHistoryLimit is the maximum number of workflow runs listed in the table
+const workflowHistoryLimit = 1000
+
 var baseStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
 	BorderForeground(lipgloss.Color("240"))
@@ -77,28 +97,48 @@ func SetupModelGithubWorkflowHistory(githubUseCase gu.UseCase, selectedRepositor
 
 	tabOptions := taboptions.NewOptions()
 
+	ti := textinput.New()
+	ti.Prompt = "Filter: "
+	ti.Placeholder = "workflow:deploy.yml status:failure event:push actor:octocat created:>=2024-01-01"
+	ti.CharLimit = 256
+
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return &ModelGithubWorkflowHistory{
 		Help:                       help.New(),
 		Keys:                       keys,
 		githubUseCase:              githubUseCase,
 		tableWorkflowHistory:       tableWorkflowHistory,
+		textInputFilter:            ti,
 		modelError:                 hdlerror.SetupModelError(),
 		SelectedRepository:         selectedRepository,
 		modelTabOptions:            tabOptions,
 		actualModelTabOptions:      tabOptions,
 		forceUpdate:                forceUpdate,
+		modelWorkflowRun:           ghworkflowrun.SetupModelGithubWorkflowRun(githubUseCase, selectedRepository),
+		modelArtifacts:             ghartifacts.SetupModelGithubArtifacts(githubUseCase, selectedRepository),
+		modelDeployments:           ghdeployments.SetupModelGithubDeployments(githubUseCase, selectedRepository),
 		syncWorkflowHistoryContext: context.Background(),
 		cancelSyncWorkflowHistory:  func() {},
+		ctx:                        ctx,
+		cancel:                     cancel,
 	}
 }
 
+// Close stops the syncs of the workflow history and closes the open windows
+func (m *ModelGithubWorkflowHistory) Close() {
+	m.cancel()
+	m.cancelSyncWorkflowHistory()
+	m.modelWorkflowRun.Close()
+	m.modelArtifacts.Close()
+	m.modelDeployments.Close()
+}
+
 func (m *ModelGithubWorkflowHistory) Init() tea.Cmd {
 	openInBrowser := func() {
 		m.modelError.SetProgressMessage(fmt.Sprintf("Opening in browser..."))
 
-		var selectedWorkflow = fmt.Sprintf("https://github.com/%s/actions/runs/%d", m.SelectedRepository.RepositoryName, m.selectedWorkflowID)
-
-		err := browser.OpenInBrowser(selectedWorkflow)
+		err := browser.OpenInBrowser(m.selectedWorkflowURL)
 		if err != nil {
 			m.modelError.SetError(err)
 			m.modelError.SetErrorMessage(fmt.Sprintf("Failed to open in browser"))
@@ -157,14 +197,34 @@ func (m *ModelGithubWorkflowHistory) Init() tea.Cmd {
 
 		m.modelError.SetSuccessMessage(fmt.Sprintf("Canceled workflow"))
 	}
+
+	showArtifacts := func() {
+		if m.Workflows == nil {
+			return
+		}
+
+		m.modelArtifacts.Viewport = m.Viewport
+		m.modelArtifacts.Open(m.selectedWorkflowID, m.Workflows[m.tableWorkflowHistory.Cursor()].WorkflowName)
+	}
+
+	showDeployments := func() {
+		if m.Workflows == nil {
+			return
+		}
+
+		m.modelDeployments.Viewport = m.Viewport
+		m.modelDeployments.Open(m.selectedWorkflowID, m.Workflows[m.tableWorkflowHistory.Cursor()].WorkflowName)
+	}
 	m.actualModelTabOptions.AddOption("Open in browser", openInBrowser)
 	m.actualModelTabOptions.AddOption("Rerun failed jobs", reRunFailedJobs)
 	m.actualModelTabOptions.AddOption("Rerun workflow", reRunWorkflow)
 	m.actualModelTabOptions.AddOption("Cancel workflow", cancelWorkflow)
+	m.actualModelTabOptions.AddOption("Artifacts", showArtifacts)
+	m.actualModelTabOptions.AddOption("Deployments", showDeployments)
 
 	go func() {
 		// Make it works with to channels
-		for {
+		for m.ctx.Err() == nil {
 			if *m.forceUpdate {
 				m.tableReady = false
 				go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
@@ -177,18 +237,42 @@ func (m *ModelGithubWorkflowHistory) Init() tea.Cmd {
 }
 
 func (m *ModelGithubWorkflowHistory) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if m.lastRepository != m.SelectedRepository.RepositoryName {
+	if m.lastRepository != m.SelectedRepository.RepositoryName || m.lastBranch != m.SelectedRepository.BranchName {
 		m.tableReady = false
 		m.cancelSyncWorkflowHistory() // cancel previous sync
 
 		m.lastRepository = m.SelectedRepository.RepositoryName
+		m.lastBranch = m.SelectedRepository.BranchName
 
-		m.syncWorkflowHistoryContext, m.cancelSyncWorkflowHistory = context.WithCancel(context.Background())
+		m.syncWorkflowHistoryContext, m.cancelSyncWorkflowHistory = context.WithCancel(m.ctx)
 		go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
 	}
 
 	if m.Workflows != nil {
 		m.selectedWorkflowID = m.Workflows[m.tableWorkflowHistory.Cursor()].ID
+		m.selectedWorkflowURL = m.Workflows[m.tableWorkflowHistory.Cursor()].HTMLURL
+	}
+
+	if m.modelWorkflowRun.IsOpen() {
+		m.modelWorkflowRun.Viewport = m.Viewport
+		_, cmd := m.modelWorkflowRun.Update(msg)
+		return m, cmd
+	}
+
+	if m.modelArtifacts.IsOpen() {
+		m.modelArtifacts.Viewport = m.Viewport
+		_, cmd := m.modelArtifacts.Update(msg)
+		return m, cmd
+	}
+
+	if m.modelDeployments.IsOpen() {
+		m.modelDeployments.Viewport = m.Viewport
+		_, cmd := m.modelDeployments.Update(msg)
+		return m, cmd
+	}
+
+	if m.isFilterFocused {
+		return m, m.updateFilter(msg)
 	}
 
 	var cmds []tea.Cmd
@@ -196,9 +280,20 @@ func (m *ModelGithubWorkflowHistory) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
+		case "f", "/":
+			m.isFilterFocused = true
+			m.tableWorkflowHistory.Blur()
+			return m, m.textInputFilter.Focus()
 		case "r", "R":
 			m.tableReady = false
 			go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
+		case "enter":
+			// Show the jobs of the selected run if no option is selected
+			if m.tableReady && m.Workflows != nil && !m.actualModelTabOptions.IsOptionSelected() {
+				m.modelWorkflowRun.Viewport = m.Viewport
+				m.modelWorkflowRun.Open(m.selectedWorkflowID, m.Workflows[m.tableWorkflowHistory.Cursor()].WorkflowName)
+				return m, nil
+			}
 		}
 	}
 
@@ -211,6 +306,59 @@ func (m *ModelGithubWorkflowHistory) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateFilter handles the keys while the filter bar is being edited, enter applies the query and esc discards it
+func (m *ModelGithubWorkflowHistory) updateFilter(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			filter, err := parseHistoryFilter(m.textInputFilter.Value())
+			if err != nil {
+				m.modelError.Reset()
+				m.modelError.SetError(err)
+				m.modelError.SetErrorMessage(fmt.Sprintf("Invalid filter: %s", err))
+				return nil
+			}
+
+			m.filter = filter
+			m.filterQuery = strings.Join(strings.Fields(m.textInputFilter.Value()), " ")
+			m.textInputFilter.SetValue(m.filterQuery)
+			m.blurFilter()
+
+			m.tableReady = false
+			go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
+			return nil
+		case "esc":
+			m.textInputFilter.SetValue(m.filterQuery)
+			m.blurFilter()
+			return nil
+		case "shift+left", "shift+right":
+			return nil
+		}
+	}
+
+	m.textInputFilter, cmd = m.textInputFilter.Update(msg)
+	return cmd
+}
+
+// FilterByWorkflow shows only the runs of the given workflow file, and refreshes the history
+func (m *ModelGithubWorkflowHistory) FilterByWorkflow(workflowFile string) {
+	m.filter = gu.HistoryFilter{Workflow: workflowFile}
+	m.filterQuery = "workflow:" + path.Base(workflowFile)
+	m.textInputFilter.SetValue(m.filterQuery)
+	m.blurFilter()
+
+	m.tableReady = false
+	go m.syncWorkflowHistory(m.syncWorkflowHistoryContext)
+}
+
+func (m *ModelGithubWorkflowHistory) blurFilter() {
+	m.isFilterFocused = false
+	m.textInputFilter.Blur()
+	m.tableWorkflowHistory.Focus()
+}
+
 func (m *ModelGithubWorkflowHistory) syncWorkflowHistory(ctx context.Context) {
 	m.modelError.Reset()
 	m.modelError.SetProgressMessage(
@@ -226,6 +374,8 @@ func (m *ModelGithubWorkflowHistory) syncWorkflowHistory(ctx context.Context) {
 	workflowHistory, err := m.githubUseCase.GetWorkflowHistory(ctx, gu.GetWorkflowHistoryInput{
 		Repository: m.SelectedRepository.RepositoryName,
 		Branch:     m.SelectedRepository.BranchName,
+		Limit:      workflowHistoryLimit,
+		Filter:     m.filter,
 	})
 	if errors.Is(err, context.Canceled) {
 		return
@@ -237,7 +387,11 @@ func (m *ModelGithubWorkflowHistory) syncWorkflowHistory(ctx context.Context) {
 
 	if len(workflowHistory.Workflows) == 0 {
 		m.actualModelTabOptions.SetStatus(taboptions.OptionNone)
-		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s@%s] No workflows found.", m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName))
+		var message = "No workflows found."
+		if m.filterQuery != "" {
+			message = "No workflows match the filter."
+		}
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s@%s] %s", m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName, message))
 		return
 	}
 
@@ -264,6 +418,16 @@ func (m *ModelGithubWorkflowHistory) syncWorkflowHistory(ctx context.Context) {
 }
 
 func (m *ModelGithubWorkflowHistory) View() string {
+	if m.modelWorkflowRun.IsOpen() {
+		return m.modelWorkflowRun.View()
+	}
+	if m.modelArtifacts.IsOpen() {
+		return m.modelArtifacts.View()
+	}
+	if m.modelDeployments.IsOpen() {
+		return m.modelDeployments.View()
+	}
+
 	termWidth := m.Viewport.Width
 	termHeight := m.Viewport.Height
 
@@ -285,14 +449,32 @@ func (m *ModelGithubWorkflowHistory) View() string {
 		m.tableWorkflowHistory.SetColumns(newTableColumns)
 	}
 
-	m.tableWorkflowHistory.SetHeight(termHeight - 17)
-
 	doc := strings.Builder{}
+
+	// Show the filter bar only while a filter is applied or being edited
+	var tableHeight = termHeight - 17
+	if m.isFilterFocused || m.filterQuery != "" {
+		m.textInputFilter.Width = termWidth - 16
+		doc.WriteString(baseStyle.Copy().Width(termWidth - 2).Render(m.textInputFilter.View()))
+		doc.WriteString("\n")
+		tableHeight -= 3
+	}
+
+	m.tableWorkflowHistory.SetHeight(tableHeight)
 	doc.WriteString(baseStyle.Render(m.tableWorkflowHistory.View()))
 
 	return lipgloss.JoinVertical(lipgloss.Top, doc.String(), m.actualModelTabOptions.View())
 }
 
 func (m *ModelGithubWorkflowHistory) ViewStatus() string {
+	if m.modelWorkflowRun.IsOpen() {
+		return m.modelWorkflowRun.ViewStatus()
+	}
+	if m.modelArtifacts.IsOpen() {
+		return m.modelArtifacts.ViewStatus()
+	}
+	if m.modelDeployments.IsOpen() {
+		return m.modelDeployments.ViewStatus()
+	}
 	return m.modelError.View()
 }
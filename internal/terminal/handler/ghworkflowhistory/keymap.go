@@ -8,10 +8,12 @@ type keyMap struct {
 	LaunchTab teakey.Binding
 	Refresh   teakey.Binding
 	TabSwitch teakey.Binding
+	ShowJobs  teakey.Binding
+	Filter    teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.TabSwitch, k.Refresh, k.LaunchTab}
+	return []teakey.Binding{k.TabSwitch, k.Refresh, k.LaunchTab, k.ShowJobs, k.Filter}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
@@ -19,6 +21,8 @@ func (k keyMap) FullHelp() [][]teakey.Binding {
 		{k.TabSwitch},
 		{k.Refresh},
 		{k.LaunchTab},
+		{k.ShowJobs},
+		{k.Filter},
 	}
 }
 
@@ -35,8 +39,25 @@ var keys = keyMap{
 		teakey.WithKeys("shift+left", "shift+right"),
 		teakey.WithHelp("shift + (← | →)", "switch tab"),
 	),
+	ShowJobs: teakey.NewBinding(
+		teakey.WithKeys("enter"),
+		teakey.WithHelp("enter (no option)", "show jobs"),
+	),
+	Filter: teakey.NewBinding(
+		teakey.WithKeys("f", "/"),
+		teakey.WithHelp("f", "filter runs"),
+	),
 }
 
 func (m *ModelGithubWorkflowHistory) ViewHelp() string {
+	if m.modelWorkflowRun.IsOpen() {
+		return m.modelWorkflowRun.ViewHelp()
+	}
+	if m.modelArtifacts.IsOpen() {
+		return m.modelArtifacts.ViewHelp()
+	}
+	if m.modelDeployments.IsOpen() {
+		return m.modelDeployments.ViewHelp()
+	}
 	return m.Help.View(m.Keys)
 }
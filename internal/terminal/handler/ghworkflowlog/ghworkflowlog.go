@@ -0,0 +1,482 @@
+package ghworkflowlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/truncate"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+	pwl "github.com/termkit/gama/pkg/workflowlog"
+)
+
+// ModelGithubWorkflowLog shows the logs of a job or of every job of a workflow run
+type ModelGithubWorkflowLog struct {
+	// current handler's properties
+	isOpen         bool
+	title          string
+	jobID          int64 // job whose logs are shown, 0 if the logs of a whole run are shown
+	workflowID     int64
+	entries        []entry
+	expandedGroups map[int]bool
+	cursor         int // index of the entry under the cursor
+	isSearching    bool
+	searchQuery    string
+	syncLogContext context.Context
+	cancelSyncLog  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help            help.Model
+	Viewport        *viewport.Model
+	viewportLog     viewport.Model
+	textInputSearch textinput.Model
+	modelError      hdlerror.ModelError
+}
+
+// entry is a line of the viewer, the logs of a run are prefixed with a header line for every job
+type entry struct {
+	line      pwl.Line
+	group     int // index of the group across every job, -1 if the line is not in a group
+	jobHeader bool
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+var (
+	styleError   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	styleWarning = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	styleNotice  = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+	styleCommand = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	styleMuted   = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	styleGroup   = lipgloss.NewStyle().Bold(true)
+	styleMatch   = lipgloss.NewStyle().Reverse(true)
+)
+
+func SetupModelGithubWorkflowLog(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubWorkflowLog {
+	ti := textinput.New()
+	ti.Placeholder = "Search logs"
+	ti.Prompt = "/"
+	ti.CharLimit = 128
+
+	return &ModelGithubWorkflowLog{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		viewportLog:        viewport.New(0, 0),
+		textInputSearch:    ti,
+		expandedGroups:     map[int]bool{},
+		syncLogContext:     context.Background(),
+		cancelSyncLog:      func() {},
+	}
+}
+
+func (m *ModelGithubWorkflowLog) Init() tea.Cmd {
+	return nil
+}
+
+// OpenJob shows the logs of the given job
+func (m *ModelGithubWorkflowLog) OpenJob(jobID int64, jobName string) {
+	m.open(fmt.Sprintf("Job logs · %s", jobName))
+	m.jobID = jobID
+
+	go m.syncLog(m.syncLogContext)
+}
+
+// OpenRun shows the logs of every job of the given workflow run
+func (m *ModelGithubWorkflowLog) OpenRun(workflowID int64, workflowName string) {
+	m.open(fmt.Sprintf("Run #%d logs · %s", workflowID, workflowName))
+	m.workflowID = workflowID
+
+	go m.syncLog(m.syncLogContext)
+}
+
+func (m *ModelGithubWorkflowLog) open(title string) {
+	m.cancelSyncLog() // cancel previous sync
+	m.syncLogContext, m.cancelSyncLog = context.WithCancel(context.Background())
+
+	m.isOpen = true
+	m.title = title
+	m.jobID = 0
+	m.workflowID = 0
+	m.entries = nil
+	m.expandedGroups = map[int]bool{}
+	m.cursor = 0
+	m.isSearching = false
+	m.searchQuery = ""
+	m.textInputSearch.Reset()
+	m.viewportLog.GotoTop()
+}
+
+func (m *ModelGithubWorkflowLog) Close() {
+	m.cancelSyncLog()
+	m.isOpen = false
+}
+
+func (m *ModelGithubWorkflowLog) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m *ModelGithubWorkflowLog) syncLog(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Fetching logs...", m.SelectedRepository.RepositoryName))
+
+	var logs []gu.JobLog
+	if m.jobID != 0 {
+		output, err := m.githubUseCase.GetJobLogs(ctx, gu.GetJobLogsInput{
+			Repository: m.SelectedRepository.RepositoryName,
+			JobID:      m.jobID,
+		})
+		if errors.Is(err, context.Canceled) {
+			return
+		} else if err != nil {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage("Job logs cannot be fetched")
+			return
+		}
+		logs = []gu.JobLog{output.Log}
+	} else {
+		output, err := m.githubUseCase.GetWorkflowRunLogs(ctx, gu.GetWorkflowRunLogsInput{
+			Repository: m.SelectedRepository.RepositoryName,
+			WorkflowID: m.workflowID,
+		})
+		if errors.Is(err, context.Canceled) {
+			return
+		} else if err != nil {
+			m.modelError.SetError(err)
+			m.modelError.SetErrorMessage("Run logs cannot be fetched")
+			return
+		}
+		logs = output.Logs
+	}
+
+	m.entries = buildEntries(logs, m.jobID == 0)
+	m.cursor = 0
+
+	// Start at the first error, that is what the logs are opened for most of the time
+	if !m.jumpTo(m.isError, true) {
+		m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Logs fetched.", m.SelectedRepository.RepositoryName))
+		return
+	}
+
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Logs fetched, jumped to the first error.", m.SelectedRepository.RepositoryName))
+}
+
+// buildEntries flattens the logs of the jobs, numbering the groups of every job uniquely
+func buildEntries(logs []gu.JobLog, withJobHeaders bool) []entry {
+	var entries []entry
+	var groupOffset int
+
+	for _, log := range logs {
+		if withJobHeaders {
+			entries = append(entries, entry{line: pwl.Line{Text: log.JobName}, group: -1, jobHeader: true})
+		}
+
+		var groupCount int
+		for _, line := range log.Lines {
+			group := -1
+			if line.Group >= 0 {
+				group = groupOffset + line.Group
+				groupCount = max(groupCount, line.Group+1)
+			}
+			entries = append(entries, entry{line: line, group: group})
+		}
+		groupOffset += groupCount
+	}
+
+	return entries
+}
+
+func (m *ModelGithubWorkflowLog) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.isSearching {
+		return m.updateSearch(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			if m.searchQuery != "" {
+				m.searchQuery = ""
+				m.textInputSearch.Reset()
+			} else {
+				m.Close()
+			}
+		case "up":
+			m.moveCursor(-1)
+		case "down":
+			m.moveCursor(1)
+		case "pgup":
+			m.moveCursor(-m.viewportLog.Height)
+		case "pgdown":
+			m.moveCursor(m.viewportLog.Height)
+		case "home", "g":
+			m.moveCursor(-len(m.entries))
+		case "end", "G":
+			m.moveCursor(len(m.entries))
+		case "enter", " ":
+			m.toggleGroup()
+		case "e":
+			m.jumpTo(m.isError, true)
+		case "E":
+			m.jumpTo(m.isError, false)
+		case "/":
+			m.isSearching = true
+			m.textInputSearch.SetValue(m.searchQuery)
+			m.textInputSearch.CursorEnd()
+			cmd = m.textInputSearch.Focus()
+		case "n":
+			m.jumpTo(m.isMatch, true)
+		case "N":
+			m.jumpTo(m.isMatch, false)
+		case "r", "R":
+			m.cancelSyncLog()
+			m.syncLogContext, m.cancelSyncLog = context.WithCancel(context.Background())
+			go m.syncLog(m.syncLogContext)
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *ModelGithubWorkflowLog) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.isSearching = false
+			m.textInputSearch.Blur()
+			return m, nil
+		case "enter":
+			m.isSearching = false
+			m.textInputSearch.Blur()
+			m.searchQuery = m.textInputSearch.Value()
+			if m.searchQuery != "" && !m.isMatch(m.cursor) && !m.jumpTo(m.isMatch, true) {
+				m.modelError.SetDefaultMessage(fmt.Sprintf("No lines match %q.", m.searchQuery))
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInputSearch, cmd = m.textInputSearch.Update(msg)
+	return m, cmd
+}
+
+// isVisible reports whether the entry is shown, the lines of the collapsed groups are hidden
+func (m *ModelGithubWorkflowLog) isVisible(i int) bool {
+	e := m.entries[i]
+	return e.group < 0 || e.line.Kind == pwl.KindGroup || m.expandedGroups[e.group]
+}
+
+func (m *ModelGithubWorkflowLog) isError(i int) bool {
+	return m.entries[i].line.Kind == pwl.KindError
+}
+
+func (m *ModelGithubWorkflowLog) isMatch(i int) bool {
+	if m.searchQuery == "" || i >= len(m.entries) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(m.entries[i].line.PlainText()), strings.ToLower(m.searchQuery))
+}
+
+// moveCursor moves the cursor by the given number of visible entries
+func (m *ModelGithubWorkflowLog) moveCursor(delta int) {
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+
+	for i := m.cursor + step; delta != 0 && i >= 0 && i < len(m.entries); i += step {
+		if m.isVisible(i) {
+			m.cursor = i
+			delta -= step
+		}
+	}
+}
+
+// jumpTo moves the cursor to the next, or the previous, entry that satisfies match and expands its group.
+// The search wraps around the logs, and it reports whether an entry is found.
+func (m *ModelGithubWorkflowLog) jumpTo(match func(i int) bool, forward bool) bool {
+	step := 1
+	if !forward {
+		step = -1
+	}
+
+	for n := 1; n <= len(m.entries); n++ {
+		i := ((m.cursor+step*n)%len(m.entries) + len(m.entries)) % len(m.entries)
+		if match(i) {
+			m.cursor = i
+			if group := m.entries[i].group; group >= 0 {
+				m.expandedGroups[group] = true
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// toggleGroup expands or collapses the group under the cursor
+func (m *ModelGithubWorkflowLog) toggleGroup() {
+	if m.cursor >= len(m.entries) {
+		return
+	}
+
+	group := m.entries[m.cursor].group
+	if group < 0 {
+		return
+	}
+
+	m.expandedGroups[group] = !m.expandedGroups[group]
+
+	// Move the cursor to the title of the group when the line under it gets hidden
+	for !m.isVisible(m.cursor) {
+		m.cursor--
+	}
+}
+
+func (m *ModelGithubWorkflowLog) View() string {
+	width := m.Viewport.Width - 12
+	height := m.Viewport.Height - 14
+
+	header := lipgloss.NewStyle().Bold(true).Render(m.title)
+
+	var info string
+	if m.isSearching {
+		info = m.textInputSearch.View()
+	} else if m.searchQuery != "" {
+		info = styleMuted.Render(fmt.Sprintf("/%s · %d matches", m.searchQuery, m.countMatches()))
+	}
+
+	lines, selectedLine := m.renderEntries(width)
+
+	m.viewportLog.Width = width
+	m.viewportLog.Height = height
+	m.viewportLog.SetContent(strings.Join(lines, "\n"))
+
+	// Keep the line under the cursor visible
+	if selectedLine < m.viewportLog.YOffset {
+		m.viewportLog.SetYOffset(selectedLine)
+	} else if selectedLine >= m.viewportLog.YOffset+height {
+		m.viewportLog.SetYOffset(selectedLine - height + 1)
+	}
+
+	return baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, info, m.viewportLog.View()))
+}
+
+func (m *ModelGithubWorkflowLog) countMatches() int {
+	var count int
+	for i := range m.entries {
+		if m.isMatch(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// renderEntries renders the visible entries, and returns the line index of the entry under the cursor
+func (m *ModelGithubWorkflowLog) renderEntries(width int) ([]string, int) {
+	var lines []string
+	var selectedLine int
+
+	for i, e := range m.entries {
+		if !m.isVisible(i) {
+			continue
+		}
+
+		var cursor = "  "
+		if i == m.cursor {
+			cursor = "> "
+			selectedLine = len(lines)
+		}
+
+		var indent string
+		if e.group >= 0 && e.line.Kind != pwl.KindGroup {
+			indent = "  "
+		}
+
+		line := truncate.StringWithTail(cursor+indent+m.renderLine(i), uint(width), "…")
+		lines = append(lines, line+"\x1b[0m") // reset the colors that are not reset by the line itself
+	}
+
+	return lines, selectedLine
+}
+
+func (m *ModelGithubWorkflowLog) renderLine(i int) string {
+	e := m.entries[i]
+	text := strings.ReplaceAll(e.line.Text, "\t", "    ")
+
+	if m.isMatch(i) {
+		text = highlight(strings.ReplaceAll(e.line.PlainText(), "\t", "    "), m.searchQuery)
+	}
+
+	if e.jobHeader {
+		return styleGroup.Render("━━ " + text)
+	}
+
+	switch e.line.Kind {
+	case pwl.KindGroup:
+		if m.expandedGroups[e.group] {
+			return styleGroup.Render("▾ ") + text
+		}
+		return styleGroup.Render("▸ ") + text
+	case pwl.KindError:
+		return styleError.Render("Error: ") + text
+	case pwl.KindWarning:
+		return styleWarning.Render("Warning: ") + text
+	case pwl.KindNotice:
+		return styleNotice.Render("Notice: ") + text
+	case pwl.KindDebug:
+		return styleMuted.Render("Debug: " + text)
+	case pwl.KindCommand:
+		return styleCommand.Render(text)
+	default:
+		return text
+	}
+}
+
+// highlight marks the case-insensitive occurrences of query in text
+func highlight(text string, query string) string {
+	var builder strings.Builder
+
+	lowerText := strings.ToLower(text)
+	lowerQuery := strings.ToLower(query)
+	for {
+		index := strings.Index(lowerText, lowerQuery)
+		if index < 0 || len(lowerText) != len(text) {
+			builder.WriteString(text)
+			return builder.String()
+		}
+
+		builder.WriteString(text[:index])
+		builder.WriteString(styleMatch.Render(text[index : index+len(query)]))
+		text = text[index+len(query):]
+		lowerText = lowerText[index+len(query):]
+	}
+}
+
+func (m *ModelGithubWorkflowLog) ViewStatus() string {
+	return m.modelError.View()
+}
@@ -0,0 +1,66 @@
+package ghworkflowlog
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Move        teakey.Binding
+	ToggleGroup teakey.Binding
+	NextError   teakey.Binding
+	Search      teakey.Binding
+	NextMatch   teakey.Binding
+	Refresh     teakey.Binding
+	Back        teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Move, k.ToggleGroup, k.NextError, k.Search, k.NextMatch, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Move},
+		{k.ToggleGroup},
+		{k.NextError},
+		{k.Search},
+		{k.NextMatch},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	Move: teakey.NewBinding(
+		teakey.WithKeys("up", "down", "pgup", "pgdown", "g", "G"),
+		teakey.WithHelp("↑/↓/pgup/pgdown", "move"),
+	),
+	ToggleGroup: teakey.NewBinding(
+		teakey.WithKeys("enter", " "),
+		teakey.WithHelp("enter", "fold/unfold group"),
+	),
+	NextError: teakey.NewBinding(
+		teakey.WithKeys("e", "E"),
+		teakey.WithHelp("e/E", "next/prev error"),
+	),
+	Search: teakey.NewBinding(
+		teakey.WithKeys("/"),
+		teakey.WithHelp("/", "search"),
+	),
+	NextMatch: teakey.NewBinding(
+		teakey.WithKeys("n", "N"),
+		teakey.WithHelp("n/N", "next/prev match"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh logs"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back to jobs"),
+	),
+}
+
+func (m *ModelGithubWorkflowLog) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
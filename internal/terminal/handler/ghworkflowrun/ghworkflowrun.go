@@ -0,0 +1,367 @@
+package ghworkflowrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	"github.com/termkit/gama/internal/terminal/handler/ghworkflowlog"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubWorkflowRun shows the jobs and steps of a workflow run
+type ModelGithubWorkflowRun struct {
+	// current handler's properties
+	isOpen          bool
+	workflowID      int64
+	workflowName    string
+	runStatus       string
+	runConclusion   string
+	jobs            []gu.Job
+	jobCursor       int
+	runners         []gu.Runner // self-hosted runners, to tell why a job is queued
+	runnersFetched  bool
+	runnersMutex    sync.Mutex
+	watchRunContext context.Context
+	cancelWatchRun  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help         help.Model
+	Viewport     *viewport.Model
+	viewportJobs viewport.Model
+	modelError   hdlerror.ModelError
+
+	modelWorkflowLog *ghworkflowlog.ModelGithubWorkflowLog
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubWorkflowRun(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubWorkflowRun {
+	return &ModelGithubWorkflowRun{
+		Help:               help.New(),
+		Keys:               keys,
+		githubUseCase:      githubUseCase,
+		SelectedRepository: selectedRepository,
+		modelError:         hdlerror.SetupModelError(),
+		viewportJobs:       viewport.New(0, 0),
+		watchRunContext:    context.Background(),
+		cancelWatchRun:     func() {},
+		modelWorkflowLog:   ghworkflowlog.SetupModelGithubWorkflowLog(githubUseCase, selectedRepository),
+	}
+}
+
+func (m *ModelGithubWorkflowRun) Init() tea.Cmd {
+	return nil
+}
+
+// Open shows the jobs of the given workflow run, and keeps them up to date until the run completes
+func (m *ModelGithubWorkflowRun) Open(workflowID int64, workflowName string) {
+	m.isOpen = true
+	m.workflowID = workflowID
+	m.workflowName = workflowName
+	m.runStatus = ""
+	m.runConclusion = ""
+	m.jobs = nil
+	m.jobCursor = 0
+	m.viewportJobs.GotoTop()
+
+	m.runnersMutex.Lock()
+	m.runners = nil
+	m.runnersMutex.Unlock()
+	m.runnersFetched = false
+
+	m.startWatchRun()
+}
+
+func (m *ModelGithubWorkflowRun) Close() {
+	m.modelWorkflowLog.Close()
+	m.cancelWatchRun()
+	m.isOpen = false
+}
+
+func (m *ModelGithubWorkflowRun) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m *ModelGithubWorkflowRun) startWatchRun() {
+	m.cancelWatchRun() // cancel previous watch
+	m.watchRunContext, m.cancelWatchRun = context.WithCancel(context.Background())
+
+	go m.watchRun(m.watchRunContext)
+}
+
+func (m *ModelGithubWorkflowRun) watchRun(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(
+		fmt.Sprintf("[%s] Fetching jobs of run #%d...", m.SelectedRepository.RepositoryName, m.workflowID))
+
+	output, err := m.githubUseCase.WatchRun(ctx, gu.WatchRunInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		WorkflowID: m.workflowID,
+	})
+	if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Run cannot be watched")
+		return
+	}
+
+	for update := range output.Updates {
+		if update.Err != nil {
+			m.modelError.SetError(update.Err)
+			m.modelError.SetErrorMessage(fmt.Sprintf("Run #%d cannot be fetched", m.workflowID))
+			continue
+		}
+
+		m.runStatus = update.Status
+		m.runConclusion = update.Conclusion
+		m.jobs = update.Jobs
+		m.jobCursor = min(m.jobCursor, max(len(m.jobs)-1, 0))
+		m.syncRunners(ctx, update.Jobs)
+
+		m.modelError.Reset()
+		switch {
+		case update.Status == "completed":
+			m.modelError.SetSuccessMessage(fmt.Sprintf("[%s] Run #%d is completed: %s.",
+				m.SelectedRepository.RepositoryName, m.workflowID, update.Conclusion))
+		case len(update.Transitions) > 0:
+			m.modelError.SetProgressMessage(fmt.Sprintf("[%s] %s",
+				m.SelectedRepository.RepositoryName, describeTransitions(update.Transitions)))
+		default:
+			m.modelError.SetProgressMessage(fmt.Sprintf("[%s] Watching run #%d...",
+				m.SelectedRepository.RepositoryName, m.workflowID))
+		}
+	}
+}
+
+// syncRunners fetches the runners once per run if a job waits for a self-hosted runner
+func (m *ModelGithubWorkflowRun) syncRunners(ctx context.Context, jobs []gu.Job) {
+	if m.runnersFetched {
+		return
+	}
+
+	var waitsForSelfHosted bool
+	for _, job := range jobs {
+		if job.Status == "queued" && slices.ContainsFunc(job.Labels, isSelfHostedLabel) {
+			waitsForSelfHosted = true
+			break
+		}
+	}
+	if !waitsForSelfHosted {
+		return
+	}
+
+	// The runners are optional, the hint is shown without the matching runners if they cannot be listed
+	output, err := m.githubUseCase.ListRunners(ctx, gu.ListRunnersInput{
+		Repository: m.SelectedRepository.RepositoryName,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	m.runnersMutex.Lock()
+	defer m.runnersMutex.Unlock()
+
+	m.runnersFetched = true
+	if err == nil {
+		m.runners = output.Runners
+	}
+}
+
+func isSelfHostedLabel(label string) bool {
+	return strings.EqualFold(label, "self-hosted")
+}
+
+// describeQueuedJob tells which runner labels a queued job waits for, and how many online self-hosted runners have them
+func (m *ModelGithubWorkflowRun) describeQueuedJob(job gu.Job) string {
+	var hint = fmt.Sprintf("waiting for runner labels %s", strings.Join(job.Labels, ", "))
+
+	m.runnersMutex.Lock()
+	defer m.runnersMutex.Unlock()
+
+	if !slices.ContainsFunc(job.Labels, isSelfHostedLabel) || len(m.runners) == 0 {
+		return styleMuted.Render(hint)
+	}
+
+	var online, busy int
+	for _, runner := range m.runners {
+		if runner.Status == "online" && runner.HasLabels(job.Labels) {
+			online++
+			if runner.Busy {
+				busy++
+			}
+		}
+	}
+
+	switch {
+	case online == 0:
+		return styleMuted.Render(hint+" · ") + styleFailure.Render("no online runner matches")
+	case busy == online:
+		return styleMuted.Render(hint+" · ") + styleProgress.Render(fmt.Sprintf("%d matching runners, all busy", online))
+	default:
+		return styleMuted.Render(fmt.Sprintf("%s · %d matching runners online", hint, online))
+	}
+}
+
+// describeTransitions summarizes the state changes of the run and its jobs, like "build: queued → in_progress"
+func describeTransitions(transitions []gu.Transition) string {
+	var descriptions []string
+	for _, transition := range transitions {
+		var name = transition.JobName
+		if name == "" {
+			name = "run"
+		}
+
+		if transition.From == "" {
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", name, transition.To))
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s → %s", name, transition.From, transition.To))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// SelectedJob returns the job under the cursor, or nil if there is no job
+func (m *ModelGithubWorkflowRun) SelectedJob() *gu.Job {
+	if m.jobCursor >= len(m.jobs) {
+		return nil
+	}
+	return &m.jobs[m.jobCursor]
+}
+
+func (m *ModelGithubWorkflowRun) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if m.modelWorkflowLog.IsOpen() {
+		m.modelWorkflowLog.Viewport = m.Viewport
+		_, cmd = m.modelWorkflowLog.Update(msg)
+		return m, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.Close()
+		case "l":
+			if job := m.SelectedJob(); job != nil {
+				m.modelWorkflowLog.Viewport = m.Viewport
+				m.modelWorkflowLog.OpenJob(job.ID, job.Name)
+			}
+		case "L":
+			m.modelWorkflowLog.Viewport = m.Viewport
+			m.modelWorkflowLog.OpenRun(m.workflowID, m.workflowName)
+		case "up":
+			m.jobCursor = max(m.jobCursor-1, 0)
+		case "down":
+			m.jobCursor = min(m.jobCursor+1, max(len(m.jobs)-1, 0))
+		case "r", "R":
+			m.startWatchRun()
+		case "pgup", "pgdown":
+			m.viewportJobs, cmd = m.viewportJobs.Update(msg)
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *ModelGithubWorkflowRun) View() string {
+	if m.modelWorkflowLog.IsOpen() {
+		return m.modelWorkflowLog.View()
+	}
+
+	width := m.Viewport.Width - 12
+	height := m.Viewport.Height - 13
+
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Run #%d · %s", m.workflowID, m.workflowName))
+	if m.runStatus != "" {
+		header += "  " + statusIcon(m.runStatus, m.runConclusion) + " " + styleMuted.Render(runState(m.runStatus, m.runConclusion))
+	}
+
+	lines, selectedLine := m.renderJobs(width)
+
+	m.viewportJobs.Width = width
+	m.viewportJobs.Height = height
+	m.viewportJobs.SetContent(strings.Join(lines, "\n"))
+
+	// Keep the selected job visible
+	if selectedLine < m.viewportJobs.YOffset {
+		m.viewportJobs.SetYOffset(selectedLine)
+	} else if selectedLine >= m.viewportJobs.YOffset+height {
+		m.viewportJobs.SetYOffset(selectedLine - height + 1)
+	}
+
+	return baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.viewportJobs.View()))
+}
+
+// renderJobs renders the job and step tree, and returns the line index of the selected job
+func (m *ModelGithubWorkflowRun) renderJobs(width int) ([]string, int) {
+	var lines []string
+	var selectedLine int
+
+	for i, job := range m.jobs {
+		var cursor = "  "
+		var name = job.Name
+		if i == m.jobCursor {
+			cursor = "> "
+			name = lipgloss.NewStyle().Bold(true).Render(name)
+			selectedLine = len(lines)
+		}
+
+		var runner string
+		if job.RunnerName != "" {
+			runner = styleMuted.Render(" · " + job.RunnerName)
+		}
+
+		lines = append(lines, alignLine(cursor+statusIcon(job.Status, job.Conclusion)+" "+name+runner, job.Duration, width))
+
+		if job.Status == "queued" && len(job.Labels) > 0 {
+			lines = append(lines, "    "+m.describeQueuedJob(job))
+		}
+
+		for j, step := range job.Steps {
+			var branch = "├"
+			if j == len(job.Steps)-1 {
+				branch = "└"
+			}
+
+			left := fmt.Sprintf("    %s %s %s", styleMuted.Render(branch), statusIcon(step.Status, step.Conclusion), step.Name)
+			lines = append(lines, alignLine(left, step.Duration, width))
+		}
+	}
+
+	return lines, selectedLine
+}
+
+// alignLine puts right to the end of a line of the given width
+func alignLine(left string, right string, width int) string {
+	padding := width - lipgloss.Width(left) - lipgloss.Width(right)
+	return left + strings.Repeat(" ", max(1, padding)) + right
+}
+
+func (m *ModelGithubWorkflowRun) ViewStatus() string {
+	if m.modelWorkflowLog.IsOpen() {
+		return m.modelWorkflowLog.ViewStatus()
+	}
+
+	return m.modelError.View()
+}
@@ -0,0 +1,57 @@
+package ghworkflowrun
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	SelectJob teakey.Binding
+	Scroll    teakey.Binding
+	Logs      teakey.Binding
+	Refresh   teakey.Binding
+	Back      teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.SelectJob, k.Scroll, k.Logs, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.SelectJob},
+		{k.Scroll},
+		{k.Logs},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	SelectJob: teakey.NewBinding(
+		teakey.WithKeys("up", "down"),
+		teakey.WithHelp("↑/↓", "select job"),
+	),
+	Scroll: teakey.NewBinding(
+		teakey.WithKeys("pgup", "pgdown"),
+		teakey.WithHelp("pgup/pgdown", "scroll"),
+	),
+	Logs: teakey.NewBinding(
+		teakey.WithKeys("l", "L"),
+		teakey.WithHelp("l/L", "job/run logs"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh jobs"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+func (m *ModelGithubWorkflowRun) ViewHelp() string {
+	if m.modelWorkflowLog.IsOpen() {
+		return m.modelWorkflowLog.ViewHelp()
+	}
+	return m.Help.View(m.Keys)
+}
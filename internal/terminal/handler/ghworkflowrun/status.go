@@ -0,0 +1,52 @@
+package ghworkflowrun
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	styleSuccess  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	styleFailure  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	styleProgress = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	styleMuted    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+)
+
+// statusIcon returns the icon of a job or a step for its status and conclusion
+func statusIcon(status string, conclusion string) string {
+	switch status {
+	case "completed":
+		switch conclusion {
+		case "success":
+			return styleSuccess.Render("✓")
+		case "failure", "timed_out", "startup_failure":
+			return styleFailure.Render("✗")
+		case "cancelled":
+			return styleMuted.Render("⊘")
+		case "skipped":
+			return styleMuted.Render("↷")
+		default: // neutral, action_required, stale
+			return styleMuted.Render("•")
+		}
+	case "in_progress":
+		return styleProgress.Render(spinnerFrame())
+	default: // queued, waiting, pending, requested
+		return styleMuted.Render("○")
+	}
+}
+
+// spinnerFrame returns the frame of the spinner for the current time, the views are re-rendered periodically
+func spinnerFrame() string {
+	frames := spinner.MiniDot.Frames
+	return frames[int(time.Now().UnixNano()/int64(spinner.MiniDot.FPS))%len(frames)]
+}
+
+// runState returns the conclusion of a completed run or job, and its status otherwise
+func runState(status string, conclusion string) string {
+	if status == "completed" && conclusion != "" {
+		return conclusion
+	}
+	return status
+}
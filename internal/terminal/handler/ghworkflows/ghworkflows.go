@@ -0,0 +1,254 @@
+package ghworkflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
+)
+
+// ModelGithubWorkflows lists every workflow of the repository with its state and events, to enable or disable them
+type ModelGithubWorkflows struct {
+	// current handler's properties
+	isOpen               bool
+	workflows            []gu.RepositoryWorkflow
+	syncWorkflowsContext context.Context
+	cancelSyncWorkflows  context.CancelFunc
+
+	// shared properties
+	SelectedRepository *hdltypes.SelectedRepository
+
+	// use cases
+	githubUseCase gu.UseCase
+
+	// keymap
+	Keys keyMap
+
+	// models
+	Help           help.Model
+	Viewport       *viewport.Model
+	tableWorkflows table.Model
+	modelError     hdlerror.ModelError
+}
+
+var baseStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240"))
+
+func SetupModelGithubWorkflows(githubUseCase gu.UseCase, selectedRepository *hdltypes.SelectedRepository) *ModelGithubWorkflows {
+	tableWorkflows := table.New(
+		table.WithColumns(tableColumnsWorkflows),
+		table.WithRows([]table.Row{}),
+		table.WithFocused(true),
+		table.WithHeight(7),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	tableWorkflows.SetStyles(s)
+
+	return &ModelGithubWorkflows{
+		Help:                 help.New(),
+		Keys:                 keys,
+		githubUseCase:        githubUseCase,
+		SelectedRepository:   selectedRepository,
+		modelError:           hdlerror.SetupModelError(),
+		tableWorkflows:       tableWorkflows,
+		syncWorkflowsContext: context.Background(),
+		cancelSyncWorkflows:  func() {},
+	}
+}
+
+func (m *ModelGithubWorkflows) Init() tea.Cmd {
+	return nil
+}
+
+// Open lists every workflow of the selected repository, the events are read from the selected branch
+func (m *ModelGithubWorkflows) Open() {
+	m.cancelSyncWorkflows() // cancel previous sync
+	m.syncWorkflowsContext, m.cancelSyncWorkflows = context.WithCancel(context.Background())
+
+	m.isOpen = true
+	m.tableWorkflows.SetCursor(0)
+
+	go m.syncWorkflows(m.syncWorkflowsContext)
+}
+
+func (m *ModelGithubWorkflows) Close() {
+	m.cancelSyncWorkflows()
+	m.isOpen = false
+}
+
+func (m *ModelGithubWorkflows) IsOpen() bool {
+	return m.isOpen
+}
+
+func (m *ModelGithubWorkflows) syncWorkflows(ctx context.Context) {
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(
+		fmt.Sprintf("[%s@%s] Fetching workflows...", m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName))
+
+	output, err := m.githubUseCase.ListWorkflows(ctx, gu.ListWorkflowsInput{
+		Repository: m.SelectedRepository.RepositoryName,
+		Branch:     m.SelectedRepository.BranchName,
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage("Workflows cannot be listed")
+		return
+	}
+
+	if len(output.Workflows) == 0 {
+		m.workflows = nil
+		m.tableWorkflows.SetRows([]table.Row{})
+		m.modelError.SetDefaultMessage(fmt.Sprintf("[%s] No workflows found.", m.SelectedRepository.RepositoryName))
+		return
+	}
+
+	var tableRowsWorkflows []table.Row
+	for _, workflow := range output.Workflows {
+		var events = "-"
+		if len(workflow.Events) > 0 {
+			events = strings.Join(workflow.Events, ", ")
+		}
+
+		tableRowsWorkflows = append(tableRowsWorkflows, table.Row{
+			workflow.Name,
+			path.Base(workflow.Path),
+			workflow.State,
+			events,
+		})
+	}
+
+	// Keep the cursor on the same row, the list is synced again after every change of state
+	m.workflows = output.Workflows
+	m.tableWorkflows.SetRows(tableRowsWorkflows)
+	m.tableWorkflows.SetCursor(min(m.tableWorkflows.Cursor(), len(tableRowsWorkflows)-1))
+	m.modelError.SetSuccessMessage(fmt.Sprintf("[%s@%s] Workflows fetched.", m.SelectedRepository.RepositoryName, m.SelectedRepository.BranchName))
+}
+
+func (m *ModelGithubWorkflows) selectedWorkflow() *gu.RepositoryWorkflow {
+	cursor := m.tableWorkflows.Cursor()
+	if cursor < 0 || cursor >= len(m.workflows) {
+		return nil
+	}
+	return &m.workflows[cursor]
+}
+
+func (m *ModelGithubWorkflows) setWorkflowEnabled(workflow gu.RepositoryWorkflow, enable bool) {
+	var action, state = "Disabling", "disabled"
+	if enable {
+		action, state = "Enabling", "enabled"
+	}
+
+	m.modelError.Reset()
+	m.modelError.SetProgressMessage(fmt.Sprintf("%s %s...", action, workflow.Name))
+
+	var err error
+	if enable {
+		_, err = m.githubUseCase.EnableWorkflow(m.syncWorkflowsContext, gu.EnableWorkflowInput{
+			Repository: m.SelectedRepository.RepositoryName,
+			WorkflowID: workflow.ID,
+		})
+	} else {
+		_, err = m.githubUseCase.DisableWorkflow(m.syncWorkflowsContext, gu.DisableWorkflowInput{
+			Repository: m.SelectedRepository.RepositoryName,
+			WorkflowID: workflow.ID,
+		})
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	} else if err != nil {
+		m.modelError.SetError(err)
+		m.modelError.SetErrorMessage(fmt.Sprintf("%s cannot be %s", workflow.Name, state))
+		return
+	}
+
+	m.syncWorkflows(m.syncWorkflowsContext)
+	m.modelError.SetSuccessMessage(fmt.Sprintf("%s is %s.", workflow.Name, state))
+}
+
+func (m *ModelGithubWorkflows) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, isKeyMsg := msg.(tea.KeyMsg)
+	if !isKeyMsg {
+		return m, nil
+	}
+
+	switch key := keyMsg.String(); key {
+	case "esc":
+		m.Close()
+		return m, nil
+	case "r", "R":
+		go m.syncWorkflows(m.syncWorkflowsContext)
+		return m, nil
+	case "e", "d":
+		workflow := m.selectedWorkflow()
+		if workflow == nil {
+			return m, nil
+		}
+
+		var enable = key == "e"
+		if enable && workflow.State == "active" {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("%s is already enabled.", workflow.Name))
+			return m, nil
+		}
+		if !enable && workflow.State != "active" {
+			m.modelError.SetDefaultMessage(fmt.Sprintf("%s is already disabled.", workflow.Name))
+			return m, nil
+		}
+
+		go m.setWorkflowEnabled(*workflow, enable)
+		return m, nil
+	}
+
+	m.tableWorkflows, cmd = m.tableWorkflows.Update(msg)
+	return m, cmd
+}
+
+func (m *ModelGithubWorkflows) View() string {
+	termWidth := m.Viewport.Width
+	termHeight := m.Viewport.Height
+
+	var tableWidth int
+	for _, t := range tableColumnsWorkflows {
+		tableWidth += t.Width
+	}
+
+	newTableColumns := tableColumnsWorkflows
+	widthDiff := termWidth - tableWidth
+	if widthDiff > 0 {
+		newTableColumns[3].Width += widthDiff - 15
+		m.tableWorkflows.SetColumns(newTableColumns)
+		m.tableWorkflows.SetHeight(termHeight - 18)
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Workflows of %s", m.SelectedRepository.RepositoryName))
+
+	return baseStyle.Render(lipgloss.JoinVertical(lipgloss.Top, header, m.tableWorkflows.View()))
+}
+
+func (m *ModelGithubWorkflows) ViewStatus() string {
+	return m.modelError.View()
+}
@@ -0,0 +1,48 @@
+package ghworkflows
+
+import (
+	teakey "github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Enable  teakey.Binding
+	Disable teakey.Binding
+	Refresh teakey.Binding
+	Back    teakey.Binding
+}
+
+func (k keyMap) ShortHelp() []teakey.Binding {
+	return []teakey.Binding{k.Enable, k.Disable, k.Refresh, k.Back}
+}
+
+func (k keyMap) FullHelp() [][]teakey.Binding {
+	return [][]teakey.Binding{
+		{k.Enable},
+		{k.Disable},
+		{k.Refresh},
+		{k.Back},
+	}
+}
+
+var keys = keyMap{
+	Enable: teakey.NewBinding(
+		teakey.WithKeys("e"),
+		teakey.WithHelp("e", "enable"),
+	),
+	Disable: teakey.NewBinding(
+		teakey.WithKeys("d"),
+		teakey.WithHelp("d", "disable"),
+	),
+	Refresh: teakey.NewBinding(
+		teakey.WithKeys("r", "R"),
+		teakey.WithHelp("r/R", "Refresh workflows"),
+	),
+	Back: teakey.NewBinding(
+		teakey.WithKeys("esc"),
+		teakey.WithHelp("esc", "back"),
+	),
+}
+
+func (m *ModelGithubWorkflows) ViewHelp() string {
+	return m.Help.View(m.Keys)
+}
@@ -0,0 +1,12 @@
+package ghworkflows
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+)
+
+var tableColumnsWorkflows = []table.Column{
+	{Title: "Workflow", Width: 24},
+	{Title: "File", Width: 28},
+	{Title: "State", Width: 19},
+	{Title: "Events", Width: 32},
+}
@@ -10,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
+	hdlcaches "github.com/termkit/gama/internal/terminal/handler/ghcaches"
 	hdlgithubrepo "github.com/termkit/gama/internal/terminal/handler/ghrepository"
 	hdltrigger "github.com/termkit/gama/internal/terminal/handler/ghtrigger"
 	hdlWorkflow "github.com/termkit/gama/internal/terminal/handler/ghworkflow"
@@ -27,10 +28,18 @@ type model struct {
 	isTabActive       bool
 	terminalSizeReady bool
 
+	// profile properties
+	profiles   []string // names of the profiles in the config
+	profile    string   // name of the current profile
+	newSession NewSession
+
 	// Shared properties
 	SelectedRepository *hdltypes.SelectedRepository
 	lockTabs           *bool // lockTabs will be set true if test connection fails
 
+	// use cases
+	versionUseCase vu.UseCase
+
 	// models
 	viewport viewport.Model
 	timer    timer.Model
@@ -49,56 +58,118 @@ type model struct {
 
 	modelTrigger       tea.Model
 	actualModelTrigger *hdltrigger.ModelGithubTrigger
+
+	modelCaches       tea.Model
+	actualModelCaches *hdlcaches.ModelGithubCaches
+}
+
+// Session is the GitHub account the tabs work with, a session is built for every selected profile
+type Session struct {
+	Profile           string
+	GithubUseCase     gu.UseCase
+	RepositorySources gu.RepositorySources
 }
 
-func SetupTerminal(githubUseCase gu.UseCase, versionUseCase vu.UseCase) tea.Model {
+// NewSession builds the session of a profile
+type NewSession func(profile string) (*Session, error)
+
+func SetupTerminal(versionUseCase vu.UseCase, profiles []string, session *Session, newSession NewSession) tea.Model {
 	var currentTab = new(int)
-	var forceUpdateWorkflowHistory = new(bool)
 	var lockTabs = new(bool)
 
 	*lockTabs = true // by default lock tabs
 
-	tabsWithColor := []string{"Info", "Repository", "Workflow History", "Workflow", "Trigger"}
+	tabsWithColor := []string{"Info", "Repository", "Workflow History", "Workflow", "Trigger", "Caches"}
+
+	m := model{
+		lockTabs:       lockTabs,
+		currentTab:     currentTab,
+		TabsWithColor:  tabsWithColor,
+		timer:          timer.NewWithInterval(1<<63-1, time.Millisecond*200),
+		versionUseCase: versionUseCase,
+		profiles:       profiles,
+		newSession:     newSession,
+	}
+	m.setupTabs(session)
+
+	return &m
+}
+
+// setupTabs builds the models of every tab for the session, the models of the previous session are dropped
+func (m *model) setupTabs(session *Session) {
+	var forceUpdateWorkflowHistory = new(bool)
 
 	selectedRepository := hdltypes.SelectedRepository{}
 
 	// setup models
-	hdlModelInfo := hdlinfo.SetupModelInfo(githubUseCase, versionUseCase, lockTabs)
-	hdlModelGithubRepository := hdlgithubrepo.SetupModelGithubRepository(githubUseCase, &selectedRepository)
-	hdlModelWorkflowHistory := hdlworkflowhistory.SetupModelGithubWorkflowHistory(githubUseCase, &selectedRepository, forceUpdateWorkflowHistory)
-	hdlModelWorkflow := hdlWorkflow.SetupModelGithubWorkflow(githubUseCase, &selectedRepository)
-	hdlModelTrigger := hdltrigger.SetupModelGithubTrigger(githubUseCase, &selectedRepository, currentTab, forceUpdateWorkflowHistory)
-
-	m := model{
-		lockTabs:      lockTabs,
-		currentTab:    currentTab,
-		TabsWithColor: tabsWithColor,
-		timer:         timer.NewWithInterval(1<<63-1, time.Millisecond*200),
-		modelInfo:     hdlModelInfo, actualModelInfo: hdlModelInfo,
-		SelectedRepository:    &selectedRepository,
-		modelGithubRepository: hdlModelGithubRepository, actualModelGithubRepository: hdlModelGithubRepository,
-		modelWorkflowHistory: hdlModelWorkflowHistory, directModelWorkflowHistory: hdlModelWorkflowHistory,
-		modelWorkflow: hdlModelWorkflow, directModelWorkflow: hdlModelWorkflow,
-		modelTrigger: hdlModelTrigger, actualModelTrigger: hdlModelTrigger,
-	}
+	hdlModelInfo := hdlinfo.SetupModelInfo(session.GithubUseCase, m.versionUseCase, m.lockTabs, m.profiles, session.Profile)
+	hdlModelGithubRepository := hdlgithubrepo.SetupModelGithubRepository(session.GithubUseCase, &selectedRepository, session.RepositorySources)
+	hdlModelWorkflowHistory := hdlworkflowhistory.SetupModelGithubWorkflowHistory(session.GithubUseCase, &selectedRepository, forceUpdateWorkflowHistory)
+	hdlModelWorkflow := hdlWorkflow.SetupModelGithubWorkflow(session.GithubUseCase, &selectedRepository, m.currentTab, hdlModelWorkflowHistory.FilterByWorkflow)
+	hdlModelTrigger := hdltrigger.SetupModelGithubTrigger(session.GithubUseCase, &selectedRepository, m.currentTab, forceUpdateWorkflowHistory)
+	hdlModelCaches := hdlcaches.SetupModelGithubCaches(session.GithubUseCase, &selectedRepository)
+
+	m.profile = session.Profile
+	m.SelectedRepository = &selectedRepository
+	m.modelInfo, m.actualModelInfo = hdlModelInfo, hdlModelInfo
+	m.modelGithubRepository, m.actualModelGithubRepository = hdlModelGithubRepository, hdlModelGithubRepository
+	m.modelWorkflowHistory, m.directModelWorkflowHistory = hdlModelWorkflowHistory, hdlModelWorkflowHistory
+	m.modelWorkflow, m.directModelWorkflow = hdlModelWorkflow, hdlModelWorkflow
+	m.modelTrigger, m.actualModelTrigger = hdlModelTrigger, hdlModelTrigger
+	m.modelCaches, m.actualModelCaches = hdlModelCaches, hdlModelCaches
 
 	hdlModelInfo.Viewport = &m.viewport
 	hdlModelGithubRepository.Viewport = &m.viewport
 	hdlModelWorkflowHistory.Viewport = &m.viewport
 	hdlModelWorkflow.Viewport = &m.viewport
 	hdlModelTrigger.Viewport = &m.viewport
+	hdlModelCaches.Viewport = &m.viewport
+}
 
-	return &m
+// switchProfile builds every tab again for the profile and starts on the Info tab, which checks the token
+func (m *model) switchProfile(profile string) tea.Cmd {
+	if profile == m.profile {
+		return nil
+	}
+
+	session, err := m.newSession(profile)
+	if err != nil {
+		m.actualModelInfo.SetProfileError(profile, err)
+		return nil
+	}
+
+	m.closeTabs()
+	*m.currentTab = 0
+	*m.lockTabs = true
+	m.setupTabs(session)
+
+	return m.initTabs()
+}
+
+// closeTabs stops the background syncs of every tab, the goroutines of a dropped session
+// would otherwise keep polling with its client and switch the shared tabs
+func (m *model) closeTabs() {
+	m.actualModelInfo.Close()
+	m.actualModelGithubRepository.Close()
+	m.directModelWorkflowHistory.Close()
+	m.directModelWorkflow.Close()
+	m.actualModelTrigger.Close()
+	m.actualModelCaches.Close()
 }
 
 func (m *model) Init() tea.Cmd {
 	return tea.Batch(tea.EnterAltScreen,
 		m.timer.Init(),
-		m.modelInfo.Init(),
+		m.initTabs())
+}
+
+func (m *model) initTabs() tea.Cmd {
+	return tea.Batch(m.modelInfo.Init(),
 		m.modelGithubRepository.Init(),
 		m.modelWorkflowHistory.Init(),
 		m.modelWorkflow.Init(),
-		m.modelTrigger.Init())
+		m.modelTrigger.Init(),
+		m.modelCaches.Init())
 }
 
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -133,6 +204,8 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case timer.TickMsg:
 		m.timer, cmd = m.timer.Update(msg)
 		cmds = append(cmds, cmd)
+	case hdltypes.SwitchProfileMsg:
+		cmds = append(cmds, m.switchProfile(msg.Profile))
 	}
 
 	return m, tea.Batch(cmds...)
@@ -172,7 +245,8 @@ func (m *model) View() string {
 	mainDoc.WriteString("\n")
 	mainDoc.WriteString(m.headerView(renderedTabs...) + "\n")
 
-	var width = lipgloss.Width(strings.Repeat("-", m.viewport.Width)) - len(renderedTabs)
+	// The content is as wide as the padded document, leaving a column free on the right
+	var width = m.viewport.Width - 5
 	hdltypes.ScreenWidth = &width
 
 	dynamicWindowStyle := ts.WindowStyleCyan.Width(width).Height(m.viewport.Height - 20)
@@ -201,6 +275,10 @@ func (m *model) View() string {
 		mainDoc.WriteString(dynamicWindowStyle.Render(m.modelTrigger.View()))
 		operationDoc = operationWindowStyle.Render(m.actualModelTrigger.ViewStatus())
 		helpDoc = helpWindowStyle.Render(m.actualModelTrigger.ViewHelp())
+	case 5:
+		mainDoc.WriteString(dynamicWindowStyle.Render(m.modelCaches.View()))
+		operationDoc = operationWindowStyle.Render(m.actualModelCaches.ViewStatus())
+		helpDoc = helpWindowStyle.Render(m.actualModelCaches.ViewHelp())
 	}
 
 	mainDocContent := ts.DocStyle.Render(mainDoc.String())
@@ -245,16 +323,19 @@ func (m *model) handleTabContent(cmd tea.Cmd, msg tea.Msg) tea.Cmd {
 		m.modelWorkflow, cmd = m.modelWorkflow.Update(msg)
 	case 4:
 		m.modelTrigger, cmd = m.modelTrigger.Update(msg)
+	case 5:
+		m.modelCaches, cmd = m.modelCaches.Update(msg)
 	}
 	return cmd
 }
 
 func (m *model) headerView(titles ...string) string {
-	var renderedTitles string
+	// Fill the rest of the line after the tabs, the document is padded by 2 on both sides
+	var titlesWidth = 4
 	for _, t := range titles {
-		renderedTitles += t
+		titlesWidth += lipgloss.Width(t)
 	}
-	line := strings.Repeat("─", max(0, m.viewport.Width-79))
+	line := strings.Repeat("─", max(0, m.viewport.Width-titlesWidth))
 	titles = append(titles, line)
 	return lipgloss.JoinHorizontal(lipgloss.Center, titles...)
 }
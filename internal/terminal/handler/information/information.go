@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	hdlerror "github.com/termkit/gama/internal/terminal/handler/error"
+	hdltypes "github.com/termkit/gama/internal/terminal/handler/types"
 	vu "github.com/termkit/gama/internal/version/usecase"
 )
 
@@ -25,6 +26,18 @@ type ModelInfo struct {
 	// lockTabs will be set true if test connection fails
 	lockTabs *bool
 
+	// profiles of the config, switching to another profile builds every tab again
+	profiles []string
+	profile  string
+
+	// the context of the background syncs, it is cancelled when the profile is switched
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// rate limit and token of the profile, every profile has a model of its own
+	rateLimitMsg string
+	tokenMsg     string
+
 	// models
 	Help       help.Model
 	Viewport   *viewport.Model
@@ -38,6 +51,9 @@ type ModelInfo struct {
 const (
 	releaseURL = "https://github.com/termkit/gama/releases"
 
+	// rateLimitInterval is the refresh interval of the rate limit information
+	rateLimitInterval = 5 * time.Second
+
 	applicationName = `
  ..|'''.|      |     '||    ||'     |     
 .|'     '     |||     |||  |||     |||    
@@ -53,8 +69,9 @@ var (
 	applicationDescription string
 )
 
-func SetupModelInfo(githubUseCase gu.UseCase, versionUseCase vu.UseCase, lockTabs *bool) *ModelInfo {
+func SetupModelInfo(githubUseCase gu.UseCase, versionUseCase vu.UseCase, lockTabs *bool, profiles []string, profile string) *ModelInfo {
 	modelError := hdlerror.SetupModelError()
+	ctx, cancel := context.WithCancel(context.Background())
 
 	s := spinner.New()
 	s.Spinner = spinner.Pulse
@@ -67,6 +84,10 @@ func SetupModelInfo(githubUseCase gu.UseCase, versionUseCase vu.UseCase, lockTab
 		Keys:           keys,
 		modelError:     modelError,
 		lockTabs:       lockTabs,
+		profiles:       profiles,
+		profile:        profile,
+		ctx:            ctx,
+		cancel:         cancel,
 		spinner:        s,
 	}
 }
@@ -75,11 +96,32 @@ func (m *ModelInfo) Init() tea.Cmd {
 	gamaVersion = m.versionUseCase.CurrentVersion()
 	applicationDescription = fmt.Sprintf("Github Actions Manager (%s)", gamaVersion)
 
-	go m.testConnection(context.Background())
-	go m.checkUpdates(context.Background())
+	go m.testConnection(m.ctx)
+	go m.checkUpdates(m.ctx)
 	return nil
 }
 
+// Close stops the background syncs
+func (m *ModelInfo) Close() {
+	m.cancel()
+}
+
+// SetProfileError shows why the tabs cannot be built for the profile
+func (m *ModelInfo) SetProfileError(profile string, err error) {
+	m.modelError.SetError(err)
+	m.modelError.SetErrorMessage(fmt.Sprintf("Profile %s cannot be used", profile))
+}
+
+// nextProfile returns the profile after the current one, in the order of the config
+func (m *ModelInfo) nextProfile() string {
+	for i, profile := range m.profiles {
+		if profile == m.profile {
+			return m.profiles[(i+1)%len(m.profiles)]
+		}
+	}
+	return m.profiles[0]
+}
+
 func (m *ModelInfo) checkUpdates(ctx context.Context) {
 	isUpdateAvailable, version, err := m.versionUseCase.IsUpdateAvailable()
 	if err != nil {
@@ -105,6 +147,16 @@ func (m *ModelInfo) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.Keys.Quit):
 			return m, tea.Quit
+		case key.Matches(msg, m.Keys.SwitchProfile):
+			if len(m.profiles) < 2 {
+				return m, nil
+			}
+
+			profile := m.nextProfile()
+			m.modelError.SetProgressMessage(fmt.Sprintf("Switching to profile %s...", profile))
+			return m, func() tea.Msg {
+				return hdltypes.SwitchProfileMsg{Profile: profile}
+			}
 		}
 	}
 
@@ -120,7 +172,7 @@ func (m *ModelInfo) View() string {
 		Border(lipgloss.RoundedBorder()).
 		Width(m.Viewport.Width - 7)
 
-	infoDoc.WriteString(lipgloss.JoinVertical(lipgloss.Center, applicationName, applicationDescription, newVersionAvailableMsg))
+	infoDoc.WriteString(lipgloss.JoinVertical(lipgloss.Center, applicationName, applicationDescription, newVersionAvailableMsg, m.rateLimitMsg, m.tokenMsg, m.viewProfiles()))
 
 	docHeight := strings.Count(infoDoc.String(), "\n")
 	requiredNewlinesForPadding := m.Viewport.Height - docHeight - 13
@@ -130,6 +182,23 @@ func (m *ModelInfo) View() string {
 	return ws.Render(infoDoc.String())
 }
 
+// viewProfiles lists the profiles with the current one highlighted, it is empty if there is a single profile
+func (m *ModelInfo) viewProfiles() string {
+	if len(m.profiles) < 2 {
+		return ""
+	}
+
+	var profiles []string
+	for _, profile := range m.profiles {
+		if profile == m.profile {
+			profiles = append(profiles, lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).Render("["+profile+"]"))
+		} else {
+			profiles = append(profiles, lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(profile))
+		}
+	}
+	return "\nProfiles: " + strings.Join(profiles, " ")
+}
+
 func (m *ModelInfo) testConnection(ctx context.Context) {
 	ctxWithCancel, cancel := context.WithCancel(ctx)
 
@@ -149,6 +218,13 @@ func (m *ModelInfo) testConnection(ctx context.Context) {
 	defer cancel()
 
 	_, err := m.githubUseCase.ListRepositories(ctx, gu.ListRepositoriesInput{Limit: 1})
+	if ctx.Err() != nil {
+		return // the profile is switched, the tabs are locked by the new one
+	}
+
+	// The token is described even if the test fails, a missing scope is a common cause
+	go m.diagnoseToken(ctx)
+
 	if err != nil {
 		m.modelError.SetError(err)
 		m.modelError.SetErrorMessage("failed to test connection, please check your token&permission")
@@ -157,12 +233,91 @@ func (m *ModelInfo) testConnection(ctx context.Context) {
 	}
 
 	m.modelError.Reset()
-	m.modelError.SetSuccessMessage("Welcome to GAMA!")
+	if len(m.profiles) > 1 {
+		m.modelError.SetSuccessMessage(fmt.Sprintf("Welcome to GAMA! Using the %s profile.", m.profile))
+	} else {
+		m.modelError.SetSuccessMessage("Welcome to GAMA!")
+	}
 	*m.lockTabs = false
 
+	go m.syncRateLimit(ctx)
 	go m.Update(m)
 }
 
+// diagnoseToken describes the token, its user, type, expiry and the scopes that it misses
+func (m *ModelInfo) diagnoseToken(ctx context.Context) {
+	diagnosis, err := m.githubUseCase.DiagnoseToken(ctx, gu.DiagnoseTokenInput{})
+	if ctx.Err() != nil {
+		return
+	} else if err != nil {
+		m.tokenMsg = fmt.Sprintf("Token cannot be checked: %v", err)
+		return
+	}
+
+	m.tokenMsg = viewDiagnosis(diagnosis, time.Now())
+	go m.Update(m)
+}
+
+func viewDiagnosis(diagnosis *gu.DiagnoseTokenOutput, now time.Time) string {
+	var user = diagnosis.Login
+	if diagnosis.Name != "" && diagnosis.Name != diagnosis.Login {
+		user = fmt.Sprintf("%s (%s)", diagnosis.Login, diagnosis.Name)
+	}
+
+	var details = []string{fmt.Sprintf("Signed in as %s with a %s token", user, diagnosis.TokenType)}
+	switch diagnosis.TokenType {
+	case gu.TokenTypeClassic:
+		if len(diagnosis.Scopes) == 0 {
+			details = append(details, "no scopes")
+		} else {
+			details = append(details, "scopes: "+strings.Join(diagnosis.Scopes, ", "))
+		}
+	case gu.TokenTypeFineGrained:
+		details = append(details, "triggering needs the Actions read and write permission")
+	}
+
+	if !diagnosis.ExpiresAt.IsZero() {
+		var expiry = fmt.Sprintf("expires %s", diagnosis.ExpiresAt.In(time.Local).Format("2006-01-02 15:04"))
+		if remaining := diagnosis.ExpiresAt.Sub(now); remaining <= 0 {
+			expiry = "expired " + diagnosis.ExpiresAt.In(time.Local).Format("2006-01-02 15:04")
+		} else if remaining < 7*24*time.Hour && diagnosis.TokenType != gu.TokenTypeApp {
+			expiry += fmt.Sprintf(" (in %d days)", int(remaining.Hours()/24))
+		}
+		details = append(details, expiry)
+	} else if diagnosis.TokenType != gu.TokenTypeApp {
+		details = append(details, "does not expire")
+	}
+
+	var lines = []string{strings.Join(details, " · ")}
+	for _, missing := range diagnosis.MissingScopes {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#ffaf00")).
+			Render(fmt.Sprintf("Missing scope %s, needed for %s", missing.Scope, missing.Reason)))
+	}
+	return "\n" + strings.Join(lines, "\n")
+}
+
+// syncRateLimit keeps the remaining API quota up to date
+func (m *ModelInfo) syncRateLimit(ctx context.Context) {
+	ticker := time.NewTicker(rateLimitInterval)
+	defer ticker.Stop()
+
+	for {
+		rateLimit, err := m.githubUseCase.GetRateLimit(ctx, gu.GetRateLimitInput{})
+		if err != nil {
+			m.rateLimitMsg = fmt.Sprintf("API quota cannot be fetched: %v", err)
+		} else {
+			m.rateLimitMsg = fmt.Sprintf("API quota: %d/%d remaining, resets at %s",
+				rateLimit.Remaining, rateLimit.Limit, rateLimit.Reset.In(time.Local).Format("15:04:05"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (m *ModelInfo) ViewStatus() string {
 	return m.modelError.View()
 }
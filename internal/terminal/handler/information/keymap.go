@@ -5,17 +5,19 @@ import (
 )
 
 type keyMap struct {
-	NextTab teakey.Binding
-	Quit    teakey.Binding
+	NextTab       teakey.Binding
+	SwitchProfile teakey.Binding
+	Quit          teakey.Binding
 }
 
 func (k keyMap) ShortHelp() []teakey.Binding {
-	return []teakey.Binding{k.NextTab, k.Quit}
+	return []teakey.Binding{k.NextTab, k.SwitchProfile, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]teakey.Binding {
 	return [][]teakey.Binding{
 		{k.NextTab},
+		{k.SwitchProfile},
 		{k.Quit},
 	}
 }
@@ -25,6 +27,10 @@ var keys = keyMap{
 		teakey.WithKeys("shift+right"),
 		teakey.WithHelp("shift + →", "next tab"),
 	),
+	SwitchProfile: teakey.NewBinding(
+		teakey.WithKeys("p"),
+		teakey.WithHelp("p", "switch profile"),
+	),
 	Quit: teakey.NewBinding(
 		teakey.WithKeys("q", "ctrl+c"),
 		teakey.WithHelp("q", "quit"),
@@ -32,5 +38,7 @@ var keys = keyMap{
 }
 
 func (m *ModelInfo) ViewHelp() string {
-	return m.Help.View(m.Keys)
+	var keys = m.Keys
+	keys.SwitchProfile.SetEnabled(len(m.profiles) > 1)
+	return m.Help.View(keys)
 }
@@ -86,6 +86,11 @@ func (o *Options) AddOption(option string, action func()) {
 	o.optionsWithFunc[optionNumber] = action
 }
 
+// IsOptionSelected reports whether an option is selected to be launched with enter
+func (o *Options) IsOptionSelected() bool {
+	return o.cursor != 0
+}
+
 func (o *Options) executeOption() {
 	go o.optionsWithFunc[o.cursor]()
 	o.cursor = 0
@@ -123,14 +128,8 @@ func (o *Options) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch keypress := msg.String(); keypress {
-		case "1":
-			o.updateCursor(1)
-		case "2":
-			o.updateCursor(2)
-		case "3":
-			o.updateCursor(3)
-		case "4":
-			o.updateCursor(4)
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			o.updateCursor(int(keypress[0] - '0'))
 		case "enter":
 			o.executeOption()
 		}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatSize formats a size in bytes with the largest fitting binary unit, like 1.5 MB
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	var size = float64(bytes) / unit
+	var units = []string{"KB", "MB", "GB", "TB"}
+	var i int
+	for size >= unit && i < len(units)-1 {
+		size /= unit
+		i++
+	}
+
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", size), ".0") + " " + units[i]
+}
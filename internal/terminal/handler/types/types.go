@@ -8,3 +8,8 @@ type SelectedRepository struct {
 }
 
 var ScreenWidth *int
+
+// SwitchProfileMsg asks the terminal to build every tab again for the profile
+type SwitchProfileMsg struct {
+	Profile string
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/termkit/gama/internal/cli"
 	gr "github.com/termkit/gama/internal/github/repository"
 	gu "github.com/termkit/gama/internal/github/usecase"
 	th "github.com/termkit/gama/internal/terminal/handler"
@@ -16,20 +20,85 @@ import (
 var Version = "under development" // will be set by build flag
 
 func main() {
+	profile := flag.String("profile", "", "profile of the config file to use, like work")
+	flag.Usage = func() {
+		cli.New(nil, gu.RepositorySources{}, flag.CommandLine.Output(), os.Stderr).Run(context.Background(), nil)
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) > 0 && !cli.IsCommand(args[0]) {
+		fmt.Fprintf(os.Stderr, "gama: unknown command %q, see gama help\n", args[0])
+		os.Exit(cli.ExitUsage)
+	} else if len(args) > 0 && args[0] == "help" {
+		flag.Usage()
+		os.Exit(cli.ExitOK)
+	}
+
 	cfg, err := pkgconfig.LoadConfig()
 	if err != nil {
 		panic(fmt.Sprintf("failed to load config: %v", err))
 	}
 
-	githubRepository := gr.New(cfg)
-	versionRepository := vr.New(Version)
+	// The token is kept before the profile is used, the profile may have no token yet
+	if len(args) > 0 && args[0] == "auth" {
+		os.Exit(cli.Login(cfg, *profile, args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
 
-	githubUseCase := gu.New(githubRepository)
+	versionRepository := vr.New(Version)
 	versionUseCase := vu.New(versionRepository)
 
-	terminal := th.SetupTerminal(githubUseCase, versionUseCase)
+	// Every profile has its own GitHub client, they are built again when the profile is switched
+	newSession := func(profile string) (*th.Session, error) {
+		profileConfig, err := cfg.WithProfile(profile)
+		if err != nil {
+			return nil, err
+		}
+
+		githubRepository := gr.New(profileConfig)
+		githubUseCase := gu.New(githubRepository)
+
+		return &th.Session{
+			Profile:           profileConfig.Profile,
+			GithubUseCase:     githubUseCase,
+			RepositorySources: repositorySources(profileConfig.Github),
+		}, nil
+	}
+
+	session, err := newSession(*profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error selecting profile:", err)
+		os.Exit(1)
+	}
+
+	// A command runs without the terminal UI, for scripts and CI
+	if len(args) > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.New(session.GithubUseCase, session.RepositorySources, os.Stdout, os.Stderr).Run(ctx, args)
+		stop()
+		os.Exit(code)
+	}
+
+	terminal := th.SetupTerminal(versionUseCase, cfg.ProfileNames(), session, newSession)
 	if _, err := tea.NewProgram(terminal).Run(); err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
 }
+
+// repositorySources returns the sources of the repositories of a profile, its default organization is listed first
+func repositorySources(github pkgconfig.Github) gu.RepositorySources {
+	var organizations []string
+	if github.Organization != "" {
+		organizations = append(organizations, github.Organization)
+	}
+	organizations = append(organizations, github.Repositories.Organizations...)
+
+	return gu.RepositorySources{
+		Affiliation:   github.Repositories.Affiliation,
+		Visibility:    github.Repositories.Visibility,
+		SkipUser:      github.Repositories.SkipUser,
+		Organizations: organizations,
+		Include:       github.Repositories.Include,
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -11,14 +12,118 @@ import (
 const (
 	configName = ".gama"
 	configType = "yaml"
+
+	defaultGithubAPIURL = "https://api.github.com"
+	defaultGithubWebURL = "https://github.com"
+
+	// DefaultProfile is the name of the profile of the github section
+	DefaultProfile = "default"
 )
 
 type Config struct {
-	Github Github `mapstructure:"github"`
+	Github   Github            `mapstructure:"github"`
+	Profiles map[string]Github `mapstructure:"profiles"` // named accounts besides the github section, like work or ghes
+	Profile  string            `mapstructure:"profile"`  // profile used if none is selected, the default profile if it is empty
 }
 
 type Github struct {
-	Token string `mapstructure:"token"`
+	Token        string       `mapstructure:"token"`
+	TokenSource  string       `mapstructure:"token_source"`  // command, file, gh or keyring, if the token is not in the config
+	TokenCommand string       `mapstructure:"token_command"` // command that prints the token, like "pass show github"
+	TokenFile    string       `mapstructure:"token_file"`    // file that contains the token
+	APIURL       string       `mapstructure:"api_url"`
+	WebURL       string       `mapstructure:"web_url"`
+	Organization string       `mapstructure:"organization"` // default organization, its repositories are listed as well
+	Repositories Repositories `mapstructure:"repositories"`
+	App          App          `mapstructure:"app"` // GitHub App installation used instead of a token
+}
+
+// App is a GitHub App installation, its installation tokens are requested with a JWT signed by the private key
+type App struct {
+	ID             int64  `mapstructure:"id"`
+	PrivateKey     string `mapstructure:"private_key"` // path of the PEM private key of the app
+	InstallationID int64  `mapstructure:"installation_id"`
+}
+
+// IsSet reports whether the profile authenticates as a GitHub App installation
+func (a App) IsSet() bool {
+	return a.ID != 0 || a.InstallationID != 0 || a.PrivateKey != ""
+}
+
+// Repositories are the sources of the listed repositories, the user's repositories of every visibility by default
+type Repositories struct {
+	Affiliation   string   `mapstructure:"affiliation"`   // like owner,collaborator,organization_member
+	Visibility    string   `mapstructure:"visibility"`    // all, public or private
+	SkipUser      bool     `mapstructure:"skip_user"`     // list only the organizations and the included repositories
+	Organizations []string `mapstructure:"organizations"` // organizations whose repositories are listed
+	Include       []string `mapstructure:"include"`       // repositories listed explicitly, like owner/repo
+}
+
+// setDefaults fills the missing GitHub URLs. The web URL of a GitHub Enterprise Server
+// is derived from its API URL (https://HOSTNAME/api/v3) if it is not set.
+func (g *Github) setDefaults() {
+	g.APIURL = strings.TrimSuffix(g.APIURL, "/")
+	g.WebURL = strings.TrimSuffix(g.WebURL, "/")
+
+	if g.APIURL == "" {
+		g.APIURL = defaultGithubAPIURL
+	}
+
+	if g.WebURL == "" {
+		if g.APIURL == defaultGithubAPIURL {
+			g.WebURL = defaultGithubWebURL
+		} else {
+			g.WebURL = strings.TrimSuffix(g.APIURL, "/api/v3")
+		}
+	}
+}
+
+// setDefaults fills the missing GitHub URLs of every profile
+func (c *Config) setDefaults() {
+	c.Github.setDefaults()
+	for name, profile := range c.Profiles {
+		profile.setDefaults()
+		c.Profiles[name] = profile
+	}
+}
+
+// ProfileNames returns the names of the profiles, the default profile first and the others in alphabetical order
+func (c *Config) ProfileNames() []string {
+	var names []string
+	for name := range c.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return append([]string{DefaultProfile}, names...)
+}
+
+// WithProfile returns a copy of the config whose github section is the given profile, with the token
+// read from its provider. The profile of the config is used if the name is empty.
+func (c *Config) WithProfile(name string) (*Config, error) {
+	if name == "" {
+		name = c.Profile
+	}
+
+	var config = *c
+	config.Profile = DefaultProfile
+	if name != "" && name != DefaultProfile {
+		// Viper reads the keys of the profiles in lower case
+		profile, ok := c.Profiles[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("profile %q is not found, the profiles are %s", name, strings.Join(c.ProfileNames(), ", "))
+		}
+
+		config.Github = profile
+		config.Profile = strings.ToLower(name)
+	}
+
+	if err := config.Github.resolveToken(config.Profile); err != nil {
+		return nil, err
+	}
+	return &config, nil
 }
 
 func LoadConfig() (*Config, error) {
@@ -32,6 +137,9 @@ func LoadConfig() (*Config, error) {
 	viper.SetConfigType(configType)
 	viper.SetEnvKeyReplacer(strings.NewReplacer(`.`, `_`))
 	viper.BindEnv("github.token", "GITHUB_TOKEN")
+	viper.BindEnv("github.api_url", "GITHUB_API_URL")
+	viper.BindEnv("github.web_url", "GITHUB_SERVER_URL")
+	viper.BindEnv("profile", "GAMA_PROFILE")
 	viper.AutomaticEnv()
 
 	// Read the config file first
@@ -40,6 +148,7 @@ func LoadConfig() (*Config, error) {
 		if err := viper.Unmarshal(config); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal config file: %w", err)
 		}
+		config.setDefaults()
 		return config, nil
 	}
 
@@ -48,6 +157,7 @@ func LoadConfig() (*Config, error) {
 	if err := viper.Unmarshal(config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.setDefaults()
 	return config, nil
 }
 
@@ -70,7 +180,8 @@ func CheckConfig() error {
 	return fmt.Errorf("config file does not exist")
 }
 
-// SaveConfig saves the configuration to the config file.
+// SaveConfig saves the configuration to the config file. The token is kept in the OS keyring,
+// never in the config file, it fails on machines without a keyring.
 func SaveConfig(config *Config) error {
 	configPath, err := os.UserHomeDir()
 	if err != nil {
@@ -81,7 +192,17 @@ func SaveConfig(config *Config) error {
 	viper.SetConfigName(configName)
 	viper.SetConfigType(configType)
 
-	viper.Set("github.token", config.Github.Token)
+	if config.Github.Token != "" {
+		if _, err := StoreToken(DefaultProfile, config.Github.Token, false); err != nil {
+			return fmt.Errorf("failed to store token: %w", err)
+		}
+		viper.Set("github.token_source", TokenSourceKeyring)
+	} else if config.Github.TokenSource != "" {
+		viper.Set("github.token_source", config.Github.TokenSource)
+	}
+	viper.Set("github.token", "") // viper would write the token of GITHUB_TOKEN
+	viper.Set("github.api_url", config.Github.APIURL)
+	viper.Set("github.web_url", config.Github.WebURL)
 
 	if err := viper.SafeWriteConfig(); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGithub_SetDefaults(t *testing.T) {
+	t.Run("github.com", func(t *testing.T) {
+		var github Github
+		github.setDefaults()
+
+		assert.Equal(t, "https://api.github.com", github.APIURL)
+		assert.Equal(t, "https://github.com", github.WebURL)
+	})
+
+	t.Run("enterprise server", func(t *testing.T) {
+		var github = Github{APIURL: "https://ghes.example.com/api/v3/"}
+		github.setDefaults()
+
+		assert.Equal(t, "https://ghes.example.com/api/v3", github.APIURL)
+		assert.Equal(t, "https://ghes.example.com", github.WebURL)
+	})
+
+	t.Run("explicit web url", func(t *testing.T) {
+		var github = Github{APIURL: "https://api.ghes.example.com", WebURL: "https://ghes.example.com/"}
+		github.setDefaults()
+
+		assert.Equal(t, "https://api.ghes.example.com", github.APIURL)
+		assert.Equal(t, "https://ghes.example.com", github.WebURL)
+	})
+}
+
+func TestConfig_WithProfile(t *testing.T) {
+	config := &Config{
+		Github: Github{Token: "personal"},
+		Profiles: map[string]Github{
+			"work": {Token: "company", Organization: "acme"},
+			"ghes": {Token: "enterprise", APIURL: "https://ghes.example.com/api/v3"},
+		},
+	}
+
+	assert.Equal(t, []string{"default", "ghes", "work"}, config.ProfileNames())
+
+	t.Run("default profile", func(t *testing.T) {
+		selected, err := config.WithProfile("")
+		assert.NoError(t, err)
+		assert.Equal(t, "personal", selected.Github.Token)
+		assert.Equal(t, DefaultProfile, selected.Profile)
+	})
+
+	t.Run("named profile", func(t *testing.T) {
+		selected, err := config.WithProfile("Work")
+		assert.NoError(t, err)
+		assert.Equal(t, "company", selected.Github.Token)
+		assert.Equal(t, "acme", selected.Github.Organization)
+		assert.Equal(t, "work", selected.Profile)
+		assert.Equal(t, "personal", config.Github.Token)
+	})
+
+	t.Run("profile of the config", func(t *testing.T) {
+		withDefault := *config
+		withDefault.Profile = "ghes"
+
+		selected, err := withDefault.WithProfile("")
+		assert.NoError(t, err)
+		assert.Equal(t, "enterprise", selected.Github.Token)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := config.WithProfile("staging")
+		assert.ErrorContains(t, err, "default, ghes, work")
+	})
+}
@@ -0,0 +1,383 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of the token of a profile, the token_source setting selects one of them
+const (
+	TokenSourceCommand = "command" // output of token_command, like "pass show github"
+	TokenSourceFile    = "file"    // content of token_file
+	TokenSourceGh      = "gh"      // token of the GitHub CLI for the host of the profile
+	TokenSourceKeyring = "keyring" // OS keyring, or the credentials file on machines without a keyring
+)
+
+// keyringService is the service the tokens are kept under in the OS keyring, the account is the profile
+const keyringService = "gama"
+
+// tokenTimeout is how long a command may take to print the token
+const tokenTimeout = 30 * time.Second
+
+// TokenProvider reads the token of a profile from where it is kept
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// runCommand runs a command with the input and returns its output, it is replaced in tests
+var runCommand = func(ctx context.Context, input string, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s: %w: %s", name, err, message)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	return stdout.String(), nil
+}
+
+// tokenProvider returns the provider of the token of the profile. A token in the config or in GITHUB_TOKEN
+// is used as it is, otherwise the token_source setting selects the provider, or token_command or token_file.
+func (g *Github) tokenProvider(profile string) (TokenProvider, error) {
+	if g.Token != "" {
+		return staticToken(g.Token), nil
+	}
+
+	var source = g.TokenSource
+	if source == "" {
+		switch {
+		case g.TokenCommand != "":
+			source = TokenSourceCommand
+		case g.TokenFile != "":
+			source = TokenSourceFile
+		default:
+			return nil, nil
+		}
+	}
+
+	switch source {
+	case TokenSourceCommand:
+		if g.TokenCommand == "" {
+			return nil, errors.New("token_command is not set")
+		}
+		return commandToken{command: g.TokenCommand}, nil
+	case TokenSourceFile:
+		if g.TokenFile == "" {
+			return nil, errors.New("token_file is not set")
+		}
+		return fileToken{path: g.TokenFile}, nil
+	case TokenSourceGh:
+		webURL, err := url.Parse(g.WebURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid web url: %w", err)
+		}
+		return ghToken{host: webURL.Host}, nil
+	case TokenSourceKeyring:
+		return keyringToken{profile: profile}, nil
+	default:
+		return nil, fmt.Errorf("unknown token source %q, expected one of command, file, gh or keyring", source)
+	}
+}
+
+// resolveToken fills the token of the profile from its provider
+func (g *Github) resolveToken(profile string) error {
+	provider, err := g.tokenProvider(profile)
+	if err != nil {
+		return err
+	} else if provider == nil {
+		return nil // the token is checked when GitHub is reached
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
+	defer cancel()
+
+	token, err := provider.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the token of profile %s: %w", profile, err)
+	}
+
+	g.Token = token
+	return nil
+}
+
+type staticToken string
+
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// commandToken runs a command with the shell and reads the token from its first line, like pass prints it
+type commandToken struct {
+	command string
+}
+
+func (t commandToken) Token(ctx context.Context) (string, error) {
+	var output string
+	var err error
+	if runtime.GOOS == "windows" {
+		output, err = runCommand(ctx, "", "cmd", "/C", t.command)
+	} else {
+		output, err = runCommand(ctx, "", "sh", "-c", t.command)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return firstLine(output)
+}
+
+type fileToken struct {
+	path string
+}
+
+func (t fileToken) Token(ctx context.Context) (string, error) {
+	content, err := os.ReadFile(expandHome(t.path))
+	if err != nil {
+		return "", err
+	}
+
+	return firstLine(string(content))
+}
+
+// ghToken reads the token of the GitHub CLI from its hosts.yml, or asks gh if the token is in the keyring
+type ghToken struct {
+	host string
+}
+
+func (t ghToken) Token(ctx context.Context) (string, error) {
+	content, err := os.ReadFile(filepath.Join(ghConfigDir(), "hosts.yml"))
+	if err == nil {
+		var hosts map[string]struct {
+			OAuthToken string `yaml:"oauth_token"`
+		}
+		if err := yaml.Unmarshal(content, &hosts); err != nil {
+			return "", fmt.Errorf("invalid hosts.yml of gh: %w", err)
+		}
+
+		if token := hosts[t.host].OAuthToken; token != "" {
+			return token, nil
+		}
+	}
+
+	// Recent versions of gh keep the token in the keyring, and only gh knows where
+	output, err := runCommand(ctx, "", "gh", "auth", "token", "--hostname", t.host)
+	if err != nil {
+		return "", err
+	}
+	return firstLine(output)
+}
+
+// ghConfigDir returns the config directory of the GitHub CLI, like gh finds it
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if dir := os.Getenv("AppData"); runtime.GOOS == "windows" && dir != "" {
+		return filepath.Join(dir, "GitHub CLI")
+	}
+
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gh")
+}
+
+// keyringToken reads the token from the Secret Service on Linux or the Keychain on macOS.
+// Machines without a keyring, like servers without a desktop session, use the credentials file.
+type keyringToken struct {
+	profile string
+}
+
+func (t keyringToken) Token(ctx context.Context) (string, error) {
+	output, keyringErr := readKeyring(ctx, t.profile)
+	if keyringErr == nil {
+		return firstLine(output)
+	}
+
+	credentials, err := readCredentials()
+	if err != nil {
+		return "", err
+	}
+	if token := credentials[t.profile]; token != "" {
+		return token, nil
+	}
+
+	return "", fmt.Errorf("the token is neither in the keyring (%v) nor in %s", keyringErr, credentialsPath())
+}
+
+func readKeyring(ctx context.Context, profile string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return runCommand(ctx, "", "security", "find-generic-password", "-s", keyringService, "-a", profile, "-w")
+	case "windows":
+		return "", errors.New("the keyring is not supported on windows")
+	default:
+		return runCommand(ctx, "", "secret-tool", "lookup", "service", keyringService, "account", profile)
+	}
+}
+
+// ErrNoKeyring is returned by StoreToken on machines without a keyring, unless the credentials file is allowed
+var ErrNoKeyring = errors.New("no keyring is found")
+
+// lookPath finds the command of the keyring, it is replaced in tests
+var lookPath = exec.LookPath
+
+// keyringCommand returns the command of the keyring of the OS, empty if the machine has none
+func keyringCommand() string {
+	var name string
+	switch runtime.GOOS {
+	case "darwin":
+		name = "security"
+	case "windows":
+		return ""
+	default:
+		name = "secret-tool"
+	}
+
+	if _, err := lookPath(name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// StoreToken keeps the token of the profile in the OS keyring and returns where it is kept.
+// A keyring that fails, like a locked one or a cancelled prompt, returns its error. Only on machines without
+// a keyring the token is written in plain text to the credentials file, and only if insecureStorage is set.
+func StoreToken(profile string, token string, insecureStorage bool) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
+	defer cancel()
+
+	// The token is passed on the standard input, the arguments of a process are visible to other users
+	switch keyringCommand() {
+	case "security":
+		script, err := securityScript(profile, token)
+		if err != nil {
+			return "", err
+		}
+		if _, err := runCommand(ctx, script, "security", "-i"); err != nil {
+			return "", fmt.Errorf("failed to keep the token in the keychain: %w", err)
+		}
+		return "keyring", nil
+	case "secret-tool":
+		if _, err := runCommand(ctx, token, "secret-tool", "store", "--label", "gama "+profile,
+			"service", keyringService, "account", profile); err != nil {
+			return "", fmt.Errorf("failed to keep the token in the keyring: %w", err)
+		}
+		return "keyring", nil
+	}
+
+	if !insecureStorage {
+		return "", ErrNoKeyring
+	}
+
+	credentials, err := readCredentials()
+	if err != nil {
+		return "", err
+	}
+	credentials[profile] = token
+	if err := writeCredentials(credentials); err != nil {
+		return "", err
+	}
+	return credentialsPath(), nil
+}
+
+// securityScript returns the command of security -i that adds the token, the values are quoted
+// since security splits the line by spaces and reads a command from every line
+func securityScript(profile string, token string) (string, error) {
+	if strings.ContainsAny(profile+token, "\r\n\x00") {
+		return "", errors.New("the profile and the token cannot contain line breaks")
+	}
+	return fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		securityQuote(keyringService), securityQuote(profile), securityQuote(token)), nil
+}
+
+// securityQuote quotes a value for security -i, backslashes and double quotes are escaped
+func securityQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// credentialsPath returns the path of the file the tokens are kept in on machines without a keyring
+func credentialsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gama", "credentials.yml")
+}
+
+// readCredentials reads the tokens of the profiles from the credentials file, it is empty if the file does not exist
+func readCredentials() (map[string]string, error) {
+	var credentials = make(map[string]string)
+
+	content, err := os.ReadFile(credentialsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return credentials, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(content, &credentials); err != nil {
+		return nil, fmt.Errorf("invalid credentials file: %w", err)
+	}
+	return credentials, nil
+}
+
+// writeCredentials writes the credentials file, only the user can read it
+func writeCredentials(credentials map[string]string) error {
+	content, err := yaml.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+
+	path := credentialsPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return err
+	}
+
+	// WriteFile keeps the permissions of an existing file
+	return os.Chmod(path, 0o600)
+}
+
+// firstLine returns the first line of the output of a command or a file, the token
+func firstLine(output string) (string, error) {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if line = strings.TrimSpace(line); line == "" {
+		return "", errors.New("the token is empty")
+	}
+	return line, nil
+}
+
+// expandHome replaces the leading ~ of a path with the home directory of the user
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGithub_ResolveToken(t *testing.T) {
+	t.Run("token in the config", func(t *testing.T) {
+		var github = Github{Token: "config-token", TokenCommand: "exit 1"}
+
+		require.NoError(t, github.resolveToken(DefaultProfile))
+		assert.Equal(t, "config-token", github.Token)
+	})
+
+	t.Run("command", func(t *testing.T) {
+		var github = Github{TokenCommand: "printf 'command-token\\nsecond line\\n'"}
+
+		require.NoError(t, github.resolveToken(DefaultProfile))
+		assert.Equal(t, "command-token", github.Token)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(path, []byte("file-token\n"), 0o600))
+
+		var github = Github{TokenSource: TokenSourceFile, TokenFile: path}
+
+		require.NoError(t, github.resolveToken(DefaultProfile))
+		assert.Equal(t, "file-token", github.Token)
+	})
+
+	t.Run("gh", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("GH_CONFIG_DIR", dir)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(`
+github.com:
+  user: octocat
+  oauth_token: gh-token
+ghes.example.com:
+  oauth_token: ghes-token
+`), 0o600))
+
+		var github = Github{TokenSource: TokenSourceGh, WebURL: "https://ghes.example.com"}
+
+		require.NoError(t, github.resolveToken(DefaultProfile))
+		assert.Equal(t, "ghes-token", github.Token)
+	})
+
+	t.Run("keyring falls back to the credentials file", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		t.Setenv("HOME", t.TempDir())
+		stubRunCommand(t)
+		stubLookPath(t, false)
+
+		_, err := StoreToken("work", "keyring-token", false)
+		assert.ErrorIs(t, err, ErrNoKeyring)
+
+		where, err := StoreToken("work", "keyring-token", true)
+		require.NoError(t, err)
+		assert.Equal(t, credentialsPath(), where)
+
+		info, err := os.Stat(where)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		var github = Github{TokenSource: TokenSourceKeyring}
+
+		require.NoError(t, github.resolveToken("work"))
+		assert.Equal(t, "keyring-token", github.Token)
+
+		var other = Github{TokenSource: TokenSourceKeyring}
+		assert.Error(t, other.resolveToken(DefaultProfile))
+	})
+
+	t.Run("failing keyring", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		t.Setenv("HOME", t.TempDir())
+		stubRunCommand(t)
+		stubLookPath(t, true)
+
+		// A locked keyring is not a reason to write the token in plain text
+		_, err := StoreToken("work", "keyring-token", true)
+		assert.ErrorContains(t, err, "no keyring")
+		assert.NoFileExists(t, credentialsPath())
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		var github = Github{TokenSource: "vault"}
+
+		assert.ErrorContains(t, github.resolveToken(DefaultProfile), "unknown token source")
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		var github = Github{TokenCommand: "true"}
+
+		assert.ErrorContains(t, github.resolveToken(DefaultProfile), "the token is empty")
+	})
+}
+
+// stubLookPath finds the command of the keyring or not
+func stubLookPath(t *testing.T, found bool) {
+	original := lookPath
+	lookPath = func(file string) (string, error) {
+		if found {
+			return "/usr/bin/" + file, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	t.Cleanup(func() { lookPath = original })
+}
+
+// stubRunCommand makes every command fail, like on a machine without a keyring
+func stubRunCommand(t *testing.T) {
+	original := runCommand
+	runCommand = func(ctx context.Context, input string, name string, args ...string) (string, error) {
+		return "", errors.New("no keyring")
+	}
+	t.Cleanup(func() { runCommand = original })
+}
+
+func TestSecurityScript(t *testing.T) {
+	script, err := securityScript(`my "work" profile`, `ghp_a\b`)
+	require.NoError(t, err)
+	assert.Equal(t, `add-generic-password -U -s "gama" -a "my \"work\" profile" -w "ghp_a\\b"`+"\n", script)
+
+	// A line break would start another command of security
+	_, err = securityScript("work\ndelete-keychain", "ghp_token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+package workflowlog
+
+import (
+	"archive/zip"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindPlain   Kind = "plain"
+	KindGroup   Kind = "group" // first line of a ##[group] section, its text is the group title
+	KindCommand Kind = "command"
+	KindError   Kind = "error"
+	KindWarning Kind = "warning"
+	KindNotice  Kind = "notice"
+	KindDebug   Kind = "debug"
+)
+
+// Line is a line of a workflow log without its timestamp and workflow command prefix
+type Line struct {
+	Kind Kind
+	Text string
+
+	// Group is the index of the ##[group] section the line belongs to, or -1 if it is not in a group.
+	// The KindGroup line of a section has the index of the section as well.
+	Group int
+}
+
+// File is a log file of a workflow run logs archive
+type File struct {
+	Name    string // job name
+	Content string
+}
+
+// timestampPattern matches the timestamp that starts every line of a log
+var timestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+
+var commandKinds = map[string]Kind{
+	"##[command]": KindCommand,
+	"##[error]":   KindError,
+	"##[warning]": KindWarning,
+	"##[notice]":  KindNotice,
+	"##[debug]":   KindDebug,
+}
+
+// Parse splits a job log into lines and groups the lines between ##[group] and ##[endgroup]
+func Parse(content string) []Line {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimSuffix(content, "\n")
+
+	var lines []Line
+	var group = -1
+	var groupCount int
+	for _, rawLine := range strings.Split(content, "\n") {
+		text := timestampPattern.ReplaceAllString(rawLine, "")
+
+		if title, ok := strings.CutPrefix(text, "##[group]"); ok {
+			group = groupCount
+			groupCount++
+			lines = append(lines, Line{Kind: KindGroup, Text: title, Group: group})
+			continue
+		}
+
+		if strings.HasPrefix(text, "##[endgroup]") {
+			group = -1
+			continue
+		}
+
+		var kind = KindPlain
+		for prefix, commandKind := range commandKinds {
+			if message, ok := strings.CutPrefix(text, prefix); ok {
+				kind = commandKind
+				text = message
+				break
+			}
+		}
+
+		lines = append(lines, Line{Kind: kind, Text: text, Group: group})
+	}
+
+	return lines
+}
+
+// jobLogPattern matches the names of the job logs at the root of an archive, like 0_build.txt
+var jobLogPattern = regexp.MustCompile(`^(\d+)_(.+)\.txt$`)
+
+// Unzip reads the job logs of a workflow run logs archive in order.
+// Archives have a log file for every job at their root, and a directory per job with the logs of its steps.
+func Unzip(archive io.ReaderAt, size int64) ([]File, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, err
+	}
+
+	type jobLog struct {
+		order int
+		file  File
+	}
+
+	var jobLogs []jobLog
+	for _, zipFile := range reader.File {
+		if path.Dir(zipFile.Name) != "." {
+			continue // logs of the steps are already in the job logs
+		}
+
+		var order int
+		var name = strings.TrimSuffix(zipFile.Name, ".txt")
+		if matches := jobLogPattern.FindStringSubmatch(zipFile.Name); matches != nil {
+			order = atoi(matches[1])
+			name = matches[2]
+		}
+
+		content, err := readZipFile(zipFile)
+		if err != nil {
+			return nil, err
+		}
+
+		jobLogs = append(jobLogs, jobLog{order: order, file: File{Name: name, Content: content}})
+	}
+
+	sort.SliceStable(jobLogs, func(i, j int) bool {
+		return jobLogs[i].order < jobLogs[j].order
+	})
+
+	var files []File
+	for _, log := range jobLogs {
+		files = append(files, log.file)
+	}
+
+	return files, nil
+}
+
+func readZipFile(zipFile *zip.File) (string, error) {
+	file, err := zipFile.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+func atoi(s string) int {
+	var n int
+	for _, c := range s {
+		n = n*10 + int(c-'0')
+	}
+	return n
+}
+
+// ansiPattern matches the ANSI escape sequences that color the output of the steps
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;?]*[A-Za-z]")
+
+// PlainText returns the text of the line without ANSI escape sequences
+func (l Line) PlainText() string {
+	return ansiPattern.ReplaceAllString(l.Text, "")
+}
@@ -0,0 +1,82 @@
+package workflowlog
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	var content = "\ufeff2024-03-01T10:00:00.1234567Z ##[group]Run actions/checkout@v4\r\n" +
+		"2024-03-01T10:00:00.2234567Z with:\r\n" +
+		"2024-03-01T10:00:00.3234567Z ##[endgroup]\r\n" +
+		"2024-03-01T10:00:01.0000000Z ##[command]/usr/bin/git version\r\n" +
+		"2024-03-01T10:00:02.0000000Z \x1b[32mok\x1b[0m\r\n" +
+		"2024-03-01T10:00:03.0000000Z ##[error]Process completed with exit code 1.\r\n"
+
+	lines := Parse(content)
+
+	assert.Equal(t, []Line{
+		{Kind: KindGroup, Text: "Run actions/checkout@v4", Group: 0},
+		{Kind: KindPlain, Text: "with:", Group: 0},
+		{Kind: KindCommand, Text: "/usr/bin/git version", Group: -1},
+		{Kind: KindPlain, Text: "\x1b[32mok\x1b[0m", Group: -1},
+		{Kind: KindError, Text: "Process completed with exit code 1.", Group: -1},
+	}, lines)
+}
+
+func TestParse_Groups(t *testing.T) {
+	var content = "##[group]first\n" +
+		"a\n" +
+		"##[endgroup]\n" +
+		"##[group]second\n" +
+		"##[warning]b\n"
+
+	lines := Parse(content)
+
+	assert.Equal(t, []Line{
+		{Kind: KindGroup, Text: "first", Group: 0},
+		{Kind: KindPlain, Text: "a", Group: 0},
+		{Kind: KindGroup, Text: "second", Group: 1},
+		{Kind: KindWarning, Text: "b", Group: 1},
+	}, lines)
+}
+
+func TestUnzip(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for name, content := range map[string]string{
+		"10_deploy.txt":        "deploy log",
+		"2_build.txt":          "build log",
+		"build/1_Set up.txt":   "step log",
+		"deploy/1_Set up.txt":  "step log",
+		"deploy/2_Publish.txt": "step log",
+	} {
+		file, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	files, err := Unzip(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []File{
+		{Name: "build", Content: "build log"},
+		{Name: "deploy", Content: "deploy log"},
+	}, files)
+}
+
+func TestUnzip_InvalidArchive(t *testing.T) {
+	_, err := Unzip(strings.NewReader("not a zip"), 9)
+	assert.Error(t, err)
+}
+
+func TestLine_PlainText(t *testing.T) {
+	line := Line{Kind: KindPlain, Text: "\x1b[1;31mFAIL\x1b[0m tests"}
+	assert.Equal(t, "FAIL tests", line.PlainText())
+}